@@ -0,0 +1,56 @@
+package main
+
+import "embed"
+
+// embeddedTemplates bakes the templates/ directory into the binary at
+// compile time, so a single mailer binary is self-contained by default.
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// embeddedSchema bakes the schema/ directory's migrations into the
+// binary the same way embeddedTemplates does for templates.
+//
+//go:embed schema
+var embeddedSchema embed.FS
+
+// appDir and templatesDir are unset by default and meant to be set via
+// -ldflags at build time, e.g.:
+//
+//	go build -ldflags "-X main.appDir=/etc/mailer -X main.templatesDir=/etc/mailer/templates"
+//
+// When set, on-disk files rooted at these paths take precedence over
+// the templates embedded at compile time; a template missing from the
+// override root falls back to its embedded copy. This lets ops ship a
+// single self-contained binary for most deployments while still
+// supporting environments (containers, air-gapped hosts) where
+// operators need to edit templates without rebuilding. templatesDir
+// takes precedence over appDir when both are set.
+var (
+	appDir       string
+	templatesDir string
+)
+
+// templatesOverrideRoot returns the on-disk directory that should be
+// checked before falling back to the embedded templates, or "" if no
+// override is configured.
+func templatesOverrideRoot() string {
+	if templatesDir != "" {
+		return templatesDir
+	}
+	if appDir != "" {
+		return appDir + "/templates"
+	}
+	return ""
+}
+
+// schemaOverrideRoot returns the on-disk directory that should be
+// checked before falling back to the embedded schema migrations, or ""
+// if no override is configured. Unlike templates, schema has no
+// dedicated -ldflags override of its own; it rides along with appDir.
+func schemaOverrideRoot() string {
+	if appDir != "" {
+		return appDir + "/schema"
+	}
+	return ""
+}
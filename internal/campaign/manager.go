@@ -0,0 +1,706 @@
+// Package campaign drives the lifecycle of bulk email campaigns: it
+// scans the database for campaigns ready to send, pulls their
+// recipients, and hands messages off to the email processor.
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"mailer/internal/database"
+	"mailer/internal/email"
+	"mailer/internal/observability"
+	"mailer/internal/smtp"
+)
+
+// EventNotifier receives fire-and-forget operational events. It's
+// satisfied by *notify.Notifier; declaring it locally instead of
+// importing the notify package avoids an import cycle.
+type EventNotifier interface {
+	Notify(ctx context.Context, eventType string, payload map[string]interface{})
+}
+
+// EventPublisher receives fire-and-forget operational events for the
+// live /events dashboard feed. It's satisfied by *eventstream.Hub;
+// declaring it locally instead of importing the eventstream package
+// avoids an import cycle.
+type EventPublisher interface {
+	Publish(eventType string, data map[string]interface{})
+}
+
+// Status is the lifecycle state of a campaign.
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusScheduled Status = "scheduled"
+	StatusRunning   Status = "running"
+	StatusPaused    Status = "paused"
+	StatusFinished  Status = "finished"
+	StatusCancelled Status = "cancelled"
+)
+
+// Campaign is a single bulk-send campaign.
+type Campaign struct {
+	ID              int64
+	Name            string
+	Status          Status
+	TotalRecipients int64
+	TemplateName    string
+	Subject         string
+	FromAddress     string
+}
+
+// Mode controls whether this Manager actively scans for and claims
+// campaigns, or sits passively alongside other instances that do.
+type Mode string
+
+const (
+	// ModeActive runs the campaign scanner loop and takes out a lease
+	// on every campaign it dispatches.
+	ModeActive Mode = "active"
+	// ModePassive disables the scanner loop entirely. A passive
+	// instance never claims or sends a campaign; it's used to let
+	// several mailer processes share one database (e.g. one active
+	// sender plus N passive API/CLI-only instances) without the risk
+	// of double-sending.
+	ModePassive Mode = "passive"
+)
+
+// defaultLeaseDuration is how long a campaign lease is held before it
+// must be renewed; other instances may claim the campaign once a lease
+// expires without renewal.
+const defaultLeaseDuration = 30 * time.Second
+
+// Lease is a campaign this Manager currently holds the sending lock
+// for.
+type Lease struct {
+	CampaignID int64
+	ExpiresAt  time.Time
+}
+
+// Manager owns the set of campaigns currently being scanned and
+// dispatched.
+type Manager struct {
+	logger    *observability.Logger
+	db        *database.Database
+	processor *email.EnterpriseProcessor
+	templates *email.EnterpriseTemplateEngine
+
+	notifier  EventNotifier
+	publisher EventPublisher
+
+	// bounceThreshold is the failed/total ratio (0-1) a finished
+	// campaign must reach before a bounce.threshold_crossed event
+	// fires. Zero disables the check. It's read under mu alongside
+	// notifier/publisher for the same reason: SetBounceThreshold may
+	// be called concurrently with a campaign finishing.
+	bounceThreshold float64
+
+	mode          Mode
+	ownerID       string
+	leaseDuration time.Duration
+	cancel        context.CancelFunc
+
+	mu        sync.Mutex
+	campaigns map[int64]*Campaign
+	leases    map[int64]*Lease
+	pipelines map[int64]*Pipeline
+}
+
+// NewManager builds a campaign manager for the given mode and starts
+// its scanner loop unless mode is ModePassive. ownerID identifies this
+// process when taking out campaign leases; it should be stable for the
+// process's lifetime but need not be stable across restarts.
+func NewManager(
+	logger *observability.Logger,
+	db *database.Database,
+	processor *email.EnterpriseProcessor,
+	templates *email.EnterpriseTemplateEngine,
+	mode Mode,
+	ownerID string,
+) *Manager {
+	m := &Manager{
+		logger:        logger,
+		db:            db,
+		processor:     processor,
+		templates:     templates,
+		mode:          mode,
+		ownerID:       ownerID,
+		leaseDuration: defaultLeaseDuration,
+		campaigns:     make(map[int64]*Campaign),
+		leases:        make(map[int64]*Lease),
+		pipelines:     make(map[int64]*Pipeline),
+	}
+
+	if mode == ModeActive {
+		ctx, cancel := context.WithCancel(context.Background())
+		m.cancel = cancel
+		go m.scanLoop(ctx)
+		go m.renewLeasesLoop(ctx)
+	} else {
+		logger.Info(context.Background(), "Campaign scanner disabled (passive mode)")
+	}
+
+	return m
+}
+
+// Mode reports whether this Manager is actively scanning for
+// campaigns.
+func (m *Manager) Mode() Mode {
+	return m.mode
+}
+
+// OwnedLeases returns the campaigns this instance currently holds the
+// sending lease for.
+func (m *Manager) OwnedLeases() []Lease {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	leases := make([]Lease, 0, len(m.leases))
+	for _, lease := range m.leases {
+		leases = append(leases, *lease)
+	}
+	return leases
+}
+
+// HasRunningCampaigns reports whether any campaign tracked by this
+// Manager is currently StatusRunning. It gates the settings hot-reload
+// self-restart: restarting mid-campaign would drop in-flight sends.
+func (m *Manager) HasRunningCampaigns() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.campaigns {
+		if c.Status == StatusRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// setStatus transitions c under m.mu, the same lock HasRunningCampaigns
+// reads c.Status through, so a campaign's status is never torn or
+// stale between the scan/pipeline goroutines that advance it and the
+// CLI goroutine that gates a restart on it. It also persists the
+// transition to campaigns.status so a later loadReadyCampaigns scan
+// stops re-selecting a campaign that has already left StatusScheduled.
+func (m *Manager) setStatus(ctx context.Context, c *Campaign, status Status) {
+	m.mu.Lock()
+	c.Status = status
+	m.mu.Unlock()
+
+	if m.db == nil {
+		return
+	}
+	if _, err := m.db.ExecContext(ctx, `UPDATE campaigns SET status = $1 WHERE id = $2`, string(status), c.ID); err != nil {
+		m.logger.Warn(ctx, "Failed to persist campaign status", "campaign_id", c.ID, "status", status, "error", err)
+	}
+}
+
+// MarkStarted transitions c to StatusRunning and notifies admins.
+func (m *Manager) MarkStarted(ctx context.Context, c *Campaign) {
+	m.setStatus(ctx, c, StatusRunning)
+	m.notifyCampaign(ctx, "campaign.started", c)
+}
+
+// MarkPaused transitions c to StatusPaused and notifies admins.
+func (m *Manager) MarkPaused(ctx context.Context, c *Campaign) {
+	m.setStatus(ctx, c, StatusPaused)
+	m.notifyCampaign(ctx, "campaign.paused", c)
+}
+
+// MarkFinished transitions c to StatusFinished and notifies admins.
+func (m *Manager) MarkFinished(ctx context.Context, c *Campaign) {
+	m.setStatus(ctx, c, StatusFinished)
+	m.notifyCampaign(ctx, "campaign.finished", c)
+}
+
+// MarkCancelled transitions c to StatusCancelled and notifies admins.
+func (m *Manager) MarkCancelled(ctx context.Context, c *Campaign) {
+	m.setStatus(ctx, c, StatusCancelled)
+	m.notifyCampaign(ctx, "campaign.cancelled", c)
+}
+
+// StartCampaign builds a Pipeline for c, registers it, and starts
+// dispatching messages through send. It returns once every message has
+// been handed to the pipeline's queue; the send itself, and the
+// campaign's completion, happen asynchronously.
+func (m *Manager) StartCampaign(ctx context.Context, c *Campaign, messages []Message, send func(context.Context, Message) error) {
+	pipeline := NewPipeline(c.ID, c.TotalRecipients, len(messages))
+
+	m.mu.Lock()
+	m.pipelines[c.ID] = pipeline
+	m.mu.Unlock()
+
+	m.MarkStarted(ctx, c)
+
+	go pipeline.Run(m.wrapSend(c, pipeline, send))
+	go m.watchPipeline(c, pipeline)
+
+	for _, msg := range messages {
+		if !pipeline.Enqueue(msg) {
+			break
+		}
+	}
+}
+
+// wrapSend wraps send so that, after each message settles, the pipeline's
+// running sent/failed/skipped counts are published to the live event
+// stream. A nil publisher makes this a no-op passthrough.
+func (m *Manager) wrapSend(c *Campaign, pipeline *Pipeline, send func(context.Context, Message) error) func(context.Context, Message) error {
+	if m.getPublisher() == nil {
+		return send
+	}
+
+	return func(ctx context.Context, msg Message) error {
+		err := send(ctx, msg)
+
+		publisher := m.getPublisher()
+		if publisher == nil {
+			return err
+		}
+
+		sent, failed, skipped := pipeline.Counts()
+		publisher.Publish("campaign.progress", map[string]interface{}{
+			"campaign_id": c.ID,
+			"sent":        sent,
+			"failed":      failed,
+			"skipped":     skipped,
+		})
+
+		return err
+	}
+}
+
+// watchPipeline waits for pipeline to reach a terminal state and
+// reflects that back onto c's status, notifying admins and logging the
+// exact sent/failed/skipped accounting.
+func (m *Manager) watchPipeline(c *Campaign, pipeline *Pipeline) {
+	state := <-pipeline.State()
+
+	m.mu.Lock()
+	delete(m.pipelines, c.ID)
+	if state.Status == StatusFinished || state.Status == StatusCancelled {
+		delete(m.campaigns, c.ID)
+	}
+	m.mu.Unlock()
+
+	ctx := context.Background()
+	switch state.Status {
+	case StatusFinished:
+		m.MarkFinished(ctx, c)
+		m.checkBounceThreshold(ctx, c, state)
+	case StatusPaused:
+		m.MarkPaused(ctx, c)
+	case StatusCancelled:
+		m.MarkCancelled(ctx, c)
+	}
+
+	m.logger.Info(ctx, "Campaign pipeline settled",
+		"campaign_id", c.ID,
+		"status", state.Status,
+		"sent", state.Sent,
+		"failed", state.Failed,
+		"skipped", state.Skipped,
+	)
+}
+
+// StopCampaign pauses or cancels a running campaign: its pipeline
+// discards every still-queued message without sending it, cancels any
+// in-flight batch context, and flips status atomically. status must be
+// StatusPaused or StatusCancelled.
+func (m *Manager) StopCampaign(id int64, status Status) error {
+	m.mu.Lock()
+	pipeline, ok := m.pipelines[id]
+	m.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("campaign %d has no running pipeline", id)
+	}
+
+	pipeline.Stop(status)
+	return nil
+}
+
+// SetNotifier attaches the notification subsystem. It may be called at
+// any time; a nil notifier disables event emission.
+func (m *Manager) SetNotifier(notifier EventNotifier) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifier = notifier
+}
+
+// SetEventPublisher attaches the live event stream. It may be called at
+// any time; a nil publisher disables event emission.
+func (m *Manager) SetEventPublisher(publisher EventPublisher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.publisher = publisher
+}
+
+// SetBounceThreshold sets the failed/total ratio a finished campaign
+// must reach before a bounce.threshold_crossed event fires. It may be
+// called at any time; zero disables the check.
+func (m *Manager) SetBounceThreshold(percent float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bounceThreshold = percent
+}
+
+// getBounceThreshold returns the configured bounce threshold. See
+// getNotifier for why this goes through the lock.
+func (m *Manager) getBounceThreshold() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.bounceThreshold
+}
+
+// getNotifier returns the attached notifier, if any. Reading it through
+// the lock matters because SetNotifier may be called concurrently with
+// event emission, not just once before any goroutines start.
+func (m *Manager) getNotifier() EventNotifier {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.notifier
+}
+
+// getPublisher returns the attached event publisher, if any. See
+// getNotifier for why this goes through the lock.
+func (m *Manager) getPublisher() EventPublisher {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.publisher
+}
+
+// notifyCampaign emits a lifecycle event for c, if a notifier is
+// attached.
+func (m *Manager) notifyCampaign(ctx context.Context, eventType string, c *Campaign) {
+	notifier := m.getNotifier()
+	if notifier == nil {
+		return
+	}
+	notifier.Notify(ctx, eventType, map[string]interface{}{
+		"campaign_id":   c.ID,
+		"campaign_name": c.Name,
+		"status":        string(c.Status),
+	})
+}
+
+// checkBounceThreshold reports a bounce.threshold_crossed event if a
+// finished campaign's failed/total ratio met or exceeded the configured
+// threshold. A zero threshold (the default) disables the check.
+func (m *Manager) checkBounceThreshold(ctx context.Context, c *Campaign, state PipelineState) {
+	threshold := m.getBounceThreshold()
+	total := state.Sent + state.Failed + state.Skipped
+	if threshold <= 0 || total == 0 {
+		return
+	}
+
+	rate := float64(state.Failed) / float64(total)
+	if rate >= threshold {
+		m.reportBounceThresholdCrossed(ctx, c, rate)
+	}
+}
+
+// reportBounceThresholdCrossed notifies admins that a finished
+// campaign's failed/total ratio met or exceeded the configured bounce
+// alert threshold.
+func (m *Manager) reportBounceThresholdCrossed(ctx context.Context, c *Campaign, rate float64) {
+	notifier := m.getNotifier()
+	if notifier == nil {
+		return
+	}
+	notifier.Notify(ctx, "bounce.threshold_crossed", map[string]interface{}{
+		"campaign_id":   c.ID,
+		"campaign_name": c.Name,
+		"bounce_rate":   rate,
+	})
+}
+
+// scanLoop periodically looks for campaigns ready to send. It only
+// runs in ModeActive; callers must not start it for a passive Manager.
+func (m *Manager) scanLoop(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.scanOnce(ctx)
+		}
+	}
+}
+
+// scanOnce runs a single scan pass: it loads every campaign ready to
+// send, claims a lease on each before touching it, and only then loads
+// its pending recipients and starts its pipeline. A campaign another
+// instance already holds the lease for is left alone, and a campaign
+// this instance is already tracking (it has a live Pipeline from an
+// earlier tick) is skipped outright -- if persisting its running status
+// failed, loadReadyCampaigns can still return a campaign that's already
+// running here, and re-acquiring our own lease for it would start a
+// second Pipeline against the same recipient rows.
+func (m *Manager) scanOnce(ctx context.Context) {
+	campaigns, err := m.loadReadyCampaigns(ctx)
+	if err != nil {
+		m.logger.Warn(ctx, "Failed to load ready campaigns", "error", err)
+		return
+	}
+
+	for _, c := range campaigns {
+		m.mu.Lock()
+		_, alreadyTracked := m.campaigns[c.ID]
+		m.mu.Unlock()
+		if alreadyTracked {
+			continue
+		}
+
+		acquired, err := m.acquireLease(ctx, c.ID)
+		if err != nil {
+			m.logger.Warn(ctx, "Failed to acquire campaign lease", "campaign_id", c.ID, "error", err)
+			continue
+		}
+		if !acquired {
+			continue
+		}
+
+		messages, err := m.loadPendingMessages(ctx, c.ID)
+		if err != nil {
+			m.logger.Warn(ctx, "Failed to load pending recipients", "campaign_id", c.ID, "error", err)
+			continue
+		}
+		if len(messages) == 0 {
+			continue
+		}
+
+		m.mu.Lock()
+		m.campaigns[c.ID] = c
+		m.mu.Unlock()
+
+		m.StartCampaign(ctx, c, messages, m.sendCampaignMessage(c))
+	}
+}
+
+// loadReadyCampaigns queries the campaigns ready to dispatch: those a
+// scheduler elsewhere has already flipped to StatusScheduled.
+func (m *Manager) loadReadyCampaigns(ctx context.Context) ([]*Campaign, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT id, name, template_name, subject, from_address, total_recipients
+		FROM campaigns
+		WHERE status = 'scheduled'
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ready campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	var campaigns []*Campaign
+	for rows.Next() {
+		c := &Campaign{Status: StatusScheduled}
+		if err := rows.Scan(&c.ID, &c.Name, &c.TemplateName, &c.Subject, &c.FromAddress, &c.TotalRecipients); err != nil {
+			return nil, fmt.Errorf("failed to scan campaign row: %w", err)
+		}
+		campaigns = append(campaigns, c)
+	}
+	return campaigns, rows.Err()
+}
+
+// loadPendingMessages queries the not-yet-sent recipient rows for
+// campaignID, in campaign_recipients.
+func (m *Manager) loadPendingMessages(ctx context.Context, campaignID int64) ([]Message, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT recipient_id, email
+		FROM campaign_recipients
+		WHERE campaign_id = $1 AND status = 'pending'
+	`, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending recipients for campaign %d: %w", campaignID, err)
+	}
+	defer rows.Close()
+
+	var messages []Message
+	for rows.Next() {
+		var msg Message
+		if err := rows.Scan(&msg.RecipientID, &msg.RecipientEmail); err != nil {
+			return nil, fmt.Errorf("failed to scan recipient row: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// sendCampaignMessage builds the send func StartCampaign uses to
+// dispatch each of c's messages: render c's template, hand the result
+// to the processor, and record the outcome on the recipient's row so a
+// later scan doesn't pick it up again.
+func (m *Manager) sendCampaignMessage(c *Campaign) func(context.Context, Message) error {
+	return func(ctx context.Context, msg Message) error {
+		body, err := m.templates.Render(c.TemplateName, msg.Data)
+		if err != nil {
+			m.markRecipient(ctx, c.ID, msg.RecipientID, "failed")
+			return fmt.Errorf("failed to render template %q for campaign %d: %w", c.TemplateName, c.ID, err)
+		}
+
+		sendErr := m.processor.Send(ctx, smtp.Message{
+			From:    c.FromAddress,
+			To:      []string{msg.RecipientEmail},
+			Subject: c.Subject,
+			Body:    body,
+		})
+
+		status := "sent"
+		if sendErr != nil {
+			status = "failed"
+		}
+		m.markRecipient(ctx, c.ID, msg.RecipientID, status)
+
+		return sendErr
+	}
+}
+
+// markRecipient records a recipient's final send outcome so a later
+// scan's pending-recipient query doesn't re-dispatch it.
+func (m *Manager) markRecipient(ctx context.Context, campaignID, recipientID int64, status string) {
+	if _, err := m.db.ExecContext(ctx, `
+		UPDATE campaign_recipients SET status = $1, updated_at = now()
+		WHERE campaign_id = $2 AND recipient_id = $3
+	`, status, campaignID, recipientID); err != nil {
+		m.logger.Warn(ctx, "Failed to update recipient status", "campaign_id", campaignID, "recipient_id", recipientID, "error", err)
+	}
+}
+
+// acquireLease attempts to take out this instance's sending lease on
+// campaignID. It returns false, nil if another instance already holds
+// an unexpired lease. Passive instances never acquire leases.
+func (m *Manager) acquireLease(ctx context.Context, campaignID int64) (bool, error) {
+	if m.mode != ModeActive {
+		return false, nil
+	}
+
+	expiresAt := time.Now().Add(m.leaseDuration)
+
+	// Deliberately no "owner_id = EXCLUDED.owner_id" self-match clause:
+	// renewing an already-held lease is renewLeasesLoop's job. Letting
+	// acquireLease re-win its own still-valid lease is what let scanOnce
+	// re-dispatch a campaign it was already running -- this must only
+	// succeed when the existing lease has actually expired.
+	res, err := m.db.ExecContext(ctx, `
+		INSERT INTO campaign_leases (campaign_id, owner_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (campaign_id) DO UPDATE
+		SET owner_id = EXCLUDED.owner_id, expires_at = EXCLUDED.expires_at
+		WHERE campaign_leases.expires_at < now()
+	`, campaignID, m.ownerID, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease for campaign %d: %w", campaignID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check lease result for campaign %d: %w", campaignID, err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	m.mu.Lock()
+	m.leases[campaignID] = &Lease{CampaignID: campaignID, ExpiresAt: expiresAt}
+	m.mu.Unlock()
+
+	return true, nil
+}
+
+// renewLeasesLoop keeps every owned lease alive until the Manager is
+// closed or a renewal is rejected (another instance has taken over,
+// e.g. after a long GC pause or network partition).
+func (m *Manager) renewLeasesLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.leaseDuration / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.renewLeases(ctx)
+		}
+	}
+}
+
+func (m *Manager) renewLeases(ctx context.Context) {
+	m.mu.Lock()
+	campaignIDs := make([]int64, 0, len(m.leases))
+	for id := range m.leases {
+		campaignIDs = append(campaignIDs, id)
+	}
+	m.mu.Unlock()
+
+	for _, id := range campaignIDs {
+		expiresAt := time.Now().Add(m.leaseDuration)
+		res, err := m.db.ExecContext(ctx, `
+			UPDATE campaign_leases SET expires_at = $1
+			WHERE campaign_id = $2 AND owner_id = $3
+		`, expiresAt, id, m.ownerID)
+		if err != nil {
+			m.logger.Warn(ctx, "Failed to renew campaign lease", "campaign_id", id, "error", err)
+			continue
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil || rows == 0 {
+			m.logger.Warn(ctx, "Lost campaign lease", "campaign_id", id)
+			m.mu.Lock()
+			delete(m.leases, id)
+			m.mu.Unlock()
+			continue
+		}
+
+		m.mu.Lock()
+		if lease, ok := m.leases[id]; ok {
+			lease.ExpiresAt = expiresAt
+		}
+		m.mu.Unlock()
+	}
+}
+
+// releaseLeases gives up every lease this instance holds, so another
+// instance can pick those campaigns up immediately instead of waiting
+// out the lease's expiry.
+func (m *Manager) releaseLeases(ctx context.Context) {
+	m.mu.Lock()
+	campaignIDs := make([]int64, 0, len(m.leases))
+	for id := range m.leases {
+		campaignIDs = append(campaignIDs, id)
+	}
+	m.leases = make(map[int64]*Lease)
+	m.mu.Unlock()
+
+	for _, id := range campaignIDs {
+		if _, err := m.db.ExecContext(ctx, `
+			DELETE FROM campaign_leases WHERE campaign_id = $1 AND owner_id = $2
+		`, id, m.ownerID); err != nil {
+			m.logger.Warn(ctx, "Failed to release campaign lease", "campaign_id", id, "error", err)
+		}
+	}
+}
+
+// Close stops the scanner and lease-renewal loops and releases any held
+// leases. It does not stop or wait for pipelines that are still
+// running.
+func (m *Manager) Close() error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	if m.mode == ModeActive {
+		m.releaseLeases(context.Background())
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package campaign
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// TestHasRunningCampaignsRacesSafelyWithMarkStarted exercises the exact
+// pattern that backs the settings hot-reload self-restart guard: one
+// goroutine advancing a campaign's status (as the scan loop and
+// pipeline watcher do) while another reads it through
+// HasRunningCampaigns (as the CLI's restart path does). Run with
+// -race, this must not report a data race on Campaign.Status.
+func TestHasRunningCampaignsRacesSafelyWithMarkStarted(t *testing.T) {
+	m := &Manager{
+		campaigns: make(map[int64]*Campaign),
+		leases:    make(map[int64]*Lease),
+		pipelines: make(map[int64]*Pipeline),
+	}
+
+	var wg sync.WaitGroup
+	for i := int64(0); i < 50; i++ {
+		c := &Campaign{ID: i, Status: StatusScheduled}
+
+		m.mu.Lock()
+		m.campaigns[i] = c
+		m.mu.Unlock()
+
+		wg.Add(3)
+		go func(c *Campaign) {
+			defer wg.Done()
+			m.MarkStarted(context.Background(), c)
+		}(c)
+		go func(c *Campaign) {
+			defer wg.Done()
+			m.MarkPaused(context.Background(), c)
+		}(c)
+		go func() {
+			defer wg.Done()
+			m.HasRunningCampaigns()
+		}()
+	}
+	wg.Wait()
+}
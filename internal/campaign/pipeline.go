@@ -0,0 +1,242 @@
+package campaign
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Message is a single queued recipient send within a campaign's
+// Pipeline.
+type Message struct {
+	RecipientID    int64
+	RecipientEmail string
+	Data           map[string]interface{}
+}
+
+// PipelineState is published on a Pipeline's state channel exactly
+// once, when its campaign reaches a terminal status.
+type PipelineState struct {
+	CampaignID int64
+	Status     Status
+	Sent       int64
+	Failed     int64
+	Skipped    int64
+}
+
+// Pipeline owns one running campaign's pending message queue and its
+// exact sent/failed/skipped accounting. A campaign is only considered
+// done once sent+failed+skipped reaches its total recipient count --
+// not when whatever fed the queue runs dry -- so a slow SMTP flush
+// can't be mistaken for premature completion.
+//
+// The queue itself is a mutex-guarded slice rather than a channel: a
+// channel gives Enqueue/Run/Stop no way to make "take this message" and
+// "discard this message" mutually exclusive -- racing a channel send or
+// receive against ctx cancellation lets Go's select choose either side
+// pseudo-randomly, so Stop could lose the race and a dequeued message
+// would still get sent after Stop returned. Every decision to take a
+// message off the queue, or to discard it, happens under the same
+// mutex.
+type Pipeline struct {
+	campaignID int64
+	total      int64
+
+	mu      sync.Mutex
+	queue   []Message
+	stopped bool
+
+	// wake is signalled whenever a message is enqueued or the pipeline
+	// is stopped, so Run isn't left blocked on an empty queue. It only
+	// wakes Run up to go re-check the mutex-guarded state above; it
+	// never carries the message itself.
+	wake chan struct{}
+
+	// inflight tracks messages dequeue has handed to Run but that
+	// haven't been counted as sent/failed yet. Stop must wait for it to
+	// drain before publishing, or its snapshot can race ahead of Run's
+	// counter update and undercount by whatever was still in flight.
+	inflight sync.WaitGroup
+
+	state chan PipelineState
+
+	sent    int64
+	failed  int64
+	skipped int64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	completeOnce sync.Once
+}
+
+// NewPipeline builds a Pipeline for a campaign with the given total
+// recipient count and queue depth.
+func NewPipeline(campaignID, total int64, queueSize int) *Pipeline {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Pipeline{
+		campaignID: campaignID,
+		total:      total,
+		queue:      make([]Message, 0, queueSize),
+		wake:       make(chan struct{}, 1),
+		state:      make(chan PipelineState, 1),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// State returns the channel that receives this Pipeline's single
+// terminal PipelineState.
+func (p *Pipeline) State() <-chan PipelineState {
+	return p.state
+}
+
+// Enqueue adds msg to the pending queue. It returns false if the
+// pipeline has already been stopped.
+func (p *Pipeline) Enqueue(msg Message) bool {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return false
+	}
+	p.queue = append(p.queue, msg)
+	p.mu.Unlock()
+
+	p.wakeRun()
+	return true
+}
+
+// dequeue takes the next message off the queue, if any is waiting.
+func (p *Pipeline) dequeue() (Message, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.queue) == 0 {
+		return Message{}, false
+	}
+	msg := p.queue[0]
+	p.queue = p.queue[1:]
+	p.inflight.Add(1)
+	return msg, true
+}
+
+func (p *Pipeline) isStopped() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stopped
+}
+
+// checkTerminal reports whether Run should stop draining, and whether
+// that's because Stop already claimed the terminal transition. Both the
+// "stopped" flag and the skipped count Stop adds when discarding the
+// queue are read under the same mutex Stop holds while writing them, so
+// there's no window where a natural-completion check can observe the
+// post-discard counters without also observing that Stop already
+// happened -- otherwise Run could independently decide the campaign
+// "finished" on the very counts Stop produced by cancelling it.
+func (p *Pipeline) checkTerminal() (done, stoppedByCaller bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return true, true
+	}
+	if atomic.LoadInt64(&p.sent)+atomic.LoadInt64(&p.failed)+atomic.LoadInt64(&p.skipped) >= p.total {
+		return true, false
+	}
+	return false, false
+}
+
+func (p *Pipeline) wakeRun() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run drains the queue, calling send for each message, until every
+// recipient has been accounted for (sent+failed+skipped==total) or the
+// pipeline is stopped. It blocks, so callers should run it in its own
+// goroutine.
+func (p *Pipeline) Run(send func(context.Context, Message) error) {
+	for {
+		if done, stoppedByCaller := p.checkTerminal(); done {
+			if !stoppedByCaller {
+				p.complete(StatusFinished)
+			}
+			return
+		}
+
+		msg, ok := p.dequeue()
+		if !ok {
+			if p.isStopped() {
+				return
+			}
+			// Nothing queued right now; wait for Enqueue or Stop to
+			// signal before re-checking, rather than busy-looping.
+			select {
+			case <-p.wake:
+			case <-p.ctx.Done():
+			}
+			continue
+		}
+
+		if err := send(p.ctx, msg); err != nil {
+			atomic.AddInt64(&p.failed, 1)
+		} else {
+			atomic.AddInt64(&p.sent, 1)
+		}
+		p.inflight.Done()
+	}
+}
+
+// complete flips the pipeline into its terminal status and publishes
+// the final counts, the first time either Run (natural completion) or
+// Stop (pause/cancel) calls it.
+func (p *Pipeline) complete(status Status) {
+	p.completeOnce.Do(func() {
+		p.cancel()
+		select {
+		case p.state <- PipelineState{
+			CampaignID: p.campaignID,
+			Status:     status,
+			Sent:       atomic.LoadInt64(&p.sent),
+			Failed:     atomic.LoadInt64(&p.failed),
+			Skipped:    atomic.LoadInt64(&p.skipped),
+		}:
+		default:
+		}
+	})
+}
+
+// Stop cancels any in-flight batch context and discards every message
+// still queued -- counting each as skipped, never sending it -- then
+// flips the pipeline to status atomically. Marking the pipeline stopped,
+// draining its queue and accounting the discards as skipped all happen
+// under the same mutex Run's checkTerminal/dequeue use, so there's no
+// window where Run can take a message Stop has already decided to
+// discard, or independently observe the post-discard counters and
+// declare the campaign "finished" before Stop gets to flip it.
+func (p *Pipeline) Stop(status Status) {
+	p.cancel()
+
+	p.mu.Lock()
+	p.stopped = true
+	discarded := len(p.queue)
+	p.queue = nil
+	if discarded > 0 {
+		atomic.AddInt64(&p.skipped, int64(discarded))
+	}
+	p.mu.Unlock()
+
+	// A message Run had already dequeued before stopped was set is still
+	// in flight -- wait for it to finish updating sent/failed so the
+	// snapshot complete publishes below can't race ahead of it.
+	p.inflight.Wait()
+
+	p.complete(status)
+}
+
+// Counts returns the current sent/failed/skipped counters.
+func (p *Pipeline) Counts() (sent, failed, skipped int64) {
+	return atomic.LoadInt64(&p.sent), atomic.LoadInt64(&p.failed), atomic.LoadInt64(&p.skipped)
+}
@@ -0,0 +1,227 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPipelineFinishesOnExactAccounting(t *testing.T) {
+	total := int64(3)
+	p := NewPipeline(1, total, int(total))
+
+	for i := int64(0); i < total; i++ {
+		if !p.Enqueue(Message{RecipientID: i}) {
+			t.Fatalf("enqueue %d: pipeline already terminal", i)
+		}
+	}
+
+	sent := int64(0)
+	go p.Run(func(ctx context.Context, msg Message) error {
+		if msg.RecipientID == 1 {
+			return errors.New("boom")
+		}
+		sent++
+		return nil
+	})
+
+	select {
+	case state := <-p.State():
+		if state.Status != StatusFinished {
+			t.Fatalf("status = %v, want %v", state.Status, StatusFinished)
+		}
+		if state.Sent != 2 || state.Failed != 1 || state.Skipped != 0 {
+			t.Fatalf("counts = sent:%d failed:%d skipped:%d, want 2/1/0", state.Sent, state.Failed, state.Skipped)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pipeline never reached a terminal state")
+	}
+}
+
+func TestPipelineStopDiscardsQueuedMessages(t *testing.T) {
+	p := NewPipeline(1, 10, 10)
+
+	for i := int64(0); i < 5; i++ {
+		if !p.Enqueue(Message{RecipientID: i}) {
+			t.Fatalf("enqueue %d: pipeline already terminal", i)
+		}
+	}
+
+	var sent int64
+	done := make(chan struct{})
+	go func() {
+		p.Run(func(ctx context.Context, msg Message) error {
+			sent++
+			return nil
+		})
+		close(done)
+	}()
+
+	p.Stop(StatusCancelled)
+	<-done
+
+	state := <-p.State()
+	if state.Status != StatusCancelled {
+		t.Fatalf("status = %v, want %v", state.Status, StatusCancelled)
+	}
+	if state.Sent+state.Failed+state.Skipped != 5 {
+		t.Fatalf("counts don't add up to enqueued total: sent:%d failed:%d skipped:%d", state.Sent, state.Failed, state.Skipped)
+	}
+
+	if ok := p.Enqueue(Message{RecipientID: 99}); ok {
+		t.Fatal("enqueue after Stop should be rejected")
+	}
+}
+
+func TestPipelineCountsNeverExceedTotal(t *testing.T) {
+	p := NewPipeline(1, 2, 2)
+	p.Enqueue(Message{RecipientID: 1})
+	p.Enqueue(Message{RecipientID: 2})
+
+	done := make(chan struct{})
+	go func() {
+		p.Run(func(ctx context.Context, msg Message) error { return nil })
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run never returned")
+	}
+
+	sent, failed, skipped := p.Counts()
+	if sent+failed+skipped != 2 {
+		t.Fatalf("sent+failed+skipped = %d, want 2", sent+failed+skipped)
+	}
+}
+
+// TestDequeueReturnsNothingAfterStop pins down the mutual exclusion
+// Stop depends on directly: once Stop has taken the lock and cleared
+// the queue, dequeue -- the only way Run ever takes a message off it
+// -- can no longer return one of the discarded messages. This is the
+// structural guarantee a channel-based queue couldn't make: racing a
+// channel receive against ctx cancellation lets Go's select choose
+// either side, so a message already sitting in the channel buffer
+// could still be received after Stop decided to discard it.
+func TestDequeueReturnsNothingAfterStop(t *testing.T) {
+	p := NewPipeline(1, 10, 10)
+	p.Enqueue(Message{RecipientID: 1})
+	p.Enqueue(Message{RecipientID: 2})
+
+	p.Stop(StatusCancelled)
+
+	if _, ok := p.dequeue(); ok {
+		t.Fatal("dequeue returned a message Stop had already discarded")
+	}
+
+	_, _, skipped := p.Counts()
+	if skipped != 2 {
+		t.Fatalf("skipped = %d, want 2", skipped)
+	}
+}
+
+// TestPipelineStopWinsAgainstNaturalCompletion pins down the race the
+// maintainer caught: with total == enqueued, discarding the queue alone
+// can push sent+failed+skipped to total, so a lock-free natural
+// -completion check in Run can observe Stop's own post-discard counters
+// and race its sync.Once-guarded complete(StatusFinished) against Stop's
+// complete(status). Status must always reflect that Stop, not natural
+// drain, ended the campaign whenever anything was skipped.
+func TestPipelineStopWinsAgainstNaturalCompletion(t *testing.T) {
+	const total = 5
+
+	for iter := 0; iter < 200; iter++ {
+		p := NewPipeline(1, total, total)
+
+		for i := int64(0); i < total; i++ {
+			if !p.Enqueue(Message{RecipientID: i}) {
+				t.Fatalf("iter %d: enqueue %d rejected before Stop", iter, i)
+			}
+		}
+
+		done := make(chan struct{})
+		go func() {
+			p.Run(func(ctx context.Context, msg Message) error { return nil })
+			close(done)
+		}()
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Stop(StatusCancelled)
+		}()
+		wg.Wait()
+		<-done
+
+		state := <-p.State()
+		if state.Skipped > 0 && state.Status != StatusCancelled {
+			t.Fatalf("iter %d: status = %v with skipped = %d, want %v", iter, state.Status, state.Skipped, StatusCancelled)
+		}
+	}
+}
+
+// TestPipelineStopNeverSendsAMessageItDiscarded stresses the
+// Enqueue/Run/Stop race with a short sleep before Stop, so Run has
+// usually begun draining the queue when Stop races it -- the same kind
+// of scheduling gap a GC pause or a loaded scheduler exposes -- across
+// many iterations, and records
+// exactly which recipient IDs send() was invoked for. A recipient ID
+// counted as skipped must never also appear in that set: the old
+// channel-based queue could let a message be received by Run's select
+// and a later Stop call both "win", so this is the regression the
+// mutex-guarded queue exists to prevent.
+func TestPipelineStopNeverSendsAMessageItDiscarded(t *testing.T) {
+	const total = 20
+
+	for iter := 0; iter < 200; iter++ {
+		p := NewPipeline(1, total, total)
+
+		for i := int64(0); i < total; i++ {
+			if !p.Enqueue(Message{RecipientID: i}) {
+				t.Fatalf("iter %d: enqueue %d rejected before Stop", iter, i)
+			}
+		}
+
+		var mu sync.Mutex
+		sentIDs := make(map[int64]bool)
+
+		done := make(chan struct{})
+		go func() {
+			p.Run(func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				sentIDs[msg.RecipientID] = true
+				mu.Unlock()
+				return nil
+			})
+			close(done)
+		}()
+
+		// Give Run a chance to start pulling from the queue before
+		// Stop races it, widening the window the old implementation
+		// got wrong.
+		time.Sleep(time.Microsecond)
+		p.Stop(StatusCancelled)
+		<-done
+
+		state := <-p.State()
+		if state.Sent+state.Failed+state.Skipped != total {
+			t.Fatalf("iter %d: counts sent:%d failed:%d skipped:%d don't add up to %d",
+				iter, state.Sent, state.Failed, state.Skipped, total)
+		}
+
+		mu.Lock()
+		sentCount := int64(len(sentIDs))
+		mu.Unlock()
+		if sentCount != state.Sent {
+			t.Fatalf("iter %d: send() invoked for %d distinct recipients but Sent=%d", iter, sentCount, state.Sent)
+		}
+
+		if remaining, ok := p.dequeue(); ok {
+			t.Fatalf("iter %d: recipient %d still dequeuable after Stop", iter, remaining.RecipientID)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package cli implements the interactive operator console used to
+// inspect and control a running mailer process.
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"mailer/internal/campaign"
+	"mailer/internal/config"
+	"mailer/internal/database"
+	"mailer/internal/email"
+	"mailer/internal/observability"
+	"mailer/internal/smtp"
+)
+
+// SettingsEditor applies a single runtime-editable setting (e.g.
+// "processor.concurrency") and persists it.
+type SettingsEditor interface {
+	Set(ctx context.Context, key, value string) error
+}
+
+// Restarter drains and re-execs the process to pick up settings
+// changes, refusing to do so while a campaign is running.
+type Restarter interface {
+	RequestRestart(ctx context.Context) (accepted bool, reason string)
+	RestartRequired() bool
+}
+
+// Interface is the operator-facing command console.
+type Interface struct {
+	cfg             *config.Config
+	logger          *observability.Logger
+	db              *database.Database
+	processor       *email.EnterpriseProcessor
+	smtp            *smtp.EnterpriseManager
+	campaignManager *campaign.Manager
+	controller      interface {
+		SettingsEditor
+		Restarter
+	}
+}
+
+// NewInterface builds a CLI bound to the running process's
+// dependencies. controller edits settings and triggers restarts, so it
+// implements both SettingsEditor and Restarter.
+func NewInterface(
+	cfg *config.Config,
+	logger *observability.Logger,
+	db *database.Database,
+	processor *email.EnterpriseProcessor,
+	smtpManager *smtp.EnterpriseManager,
+	campaignManager *campaign.Manager,
+	controller interface {
+		SettingsEditor
+		Restarter
+	},
+) *Interface {
+	return &Interface{
+		cfg:             cfg,
+		logger:          logger,
+		db:              db,
+		processor:       processor,
+		smtp:            smtpManager,
+		campaignManager: campaignManager,
+		controller:      controller,
+	}
+}
+
+// Run reads commands from stdin until EOF or the "quit" command.
+func (i *Interface) Run() error {
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "quit" || line == "exit" {
+			return nil
+		}
+
+		i.dispatch(line)
+	}
+
+	return scanner.Err()
+}
+
+func (i *Interface) dispatch(line string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+
+	switch fields[0] {
+	case "mode":
+		fmt.Println("mode:", i.campaignManager.Mode())
+	case "leases":
+		i.printLeases()
+	case "status":
+		fmt.Println("mode:", i.campaignManager.Mode())
+		fmt.Println("restart required:", i.controller.RestartRequired())
+	case "set":
+		i.handleSet(fields[1:])
+	case "restart":
+		i.handleRestart()
+	case "campaign":
+		i.handleCampaign(fields[1:])
+	default:
+		fmt.Println("unknown command:", line)
+	}
+}
+
+// handleCampaign implements the campaign status-change path: "campaign
+// pause <id>" and "campaign cancel <id>" stop a running campaign's
+// pipeline.
+func (i *Interface) handleCampaign(args []string) {
+	if len(args) != 2 {
+		fmt.Println("usage: campaign <pause|cancel> <id>")
+		return
+	}
+
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		fmt.Println("invalid campaign id:", args[1])
+		return
+	}
+
+	var status campaign.Status
+	switch args[0] {
+	case "pause":
+		status = campaign.StatusPaused
+	case "cancel":
+		status = campaign.StatusCancelled
+	default:
+		fmt.Println("usage: campaign <pause|cancel> <id>")
+		return
+	}
+
+	if err := i.campaignManager.StopCampaign(id, status); err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	fmt.Println("campaign", id, "stop requested")
+}
+
+func (i *Interface) handleSet(args []string) {
+	if len(args) != 2 {
+		fmt.Println("usage: set <key> <value>")
+		return
+	}
+
+	if err := i.controller.Set(context.Background(), args[0], args[1]); err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	fmt.Println("setting saved")
+}
+
+func (i *Interface) handleRestart() {
+	accepted, reason := i.controller.RequestRestart(context.Background())
+	if !accepted {
+		fmt.Println("restart refused:", reason)
+		return
+	}
+	fmt.Println("restart requested")
+}
+
+func (i *Interface) printLeases() {
+	leases := i.campaignManager.OwnedLeases()
+	if len(leases) == 0 {
+		fmt.Println("no owned campaign leases")
+		return
+	}
+	for _, lease := range leases {
+		fmt.Printf("campaign %d leased until %s\n", lease.CampaignID, lease.ExpiresAt)
+	}
+}
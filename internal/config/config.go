@@ -0,0 +1,154 @@
+// Package config loads and validates the mailer's configuration from
+// the standard on-disk locations.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"mailer/internal/observability"
+)
+
+// standardLocations lists the paths checked, in order, for the YAML
+// configuration file.
+var standardLocations = []string{
+	"config.yaml",
+	filepath.Join("config", "config.yaml"),
+}
+
+// AppConfig carries top-level application metadata.
+type AppConfig struct {
+	Environment string `yaml:"environment"`
+}
+
+// DatabaseConfig describes how to connect to the backing Postgres
+// database.
+type DatabaseConfig struct {
+	DSN          string `yaml:"dsn"`
+	MaxOpenConns int    `yaml:"max_open_conns"`
+	MaxIdleConns int    `yaml:"max_idle_conns"`
+}
+
+// SMTPConfig describes the pool of outbound SMTP hosts.
+type SMTPConfig struct {
+	Hosts []SMTPHostConfig `yaml:"hosts"`
+}
+
+// SMTPHostConfig is a single SMTP relay in the pool.
+type SMTPHostConfig struct {
+	Name     string `yaml:"name"`
+	Address  string `yaml:"address"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// ProcessorConfig tunes the email processor's concurrency and rates.
+type ProcessorConfig struct {
+	Concurrency int `yaml:"concurrency"`
+	RateLimit   int `yaml:"rate_limit_per_minute"`
+}
+
+// AdminEmailSinkConfig configures the admin-email notification sink.
+type AdminEmailSinkConfig struct {
+	Enabled    bool     `yaml:"enabled"`
+	From       string   `yaml:"from"`
+	Recipients []string `yaml:"recipients"`
+}
+
+// WebhookSinkConfig configures a single outbound notification webhook.
+type WebhookSinkConfig struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+}
+
+// SlackSinkConfig configures the Slack-style chat notification sink.
+type SlackSinkConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+// NotificationsConfig controls the admin notification subsystem: which
+// sinks are active and how each is addressed.
+type NotificationsConfig struct {
+	AdminEmail AdminEmailSinkConfig `yaml:"admin_email"`
+	Webhooks   []WebhookSinkConfig  `yaml:"webhooks"`
+	Slack      SlackSinkConfig      `yaml:"slack"`
+
+	// BounceThresholdPercent is the failed/total ratio (0-1) a finished
+	// campaign must reach before a bounce.threshold_crossed event fires.
+	// Zero disables the check.
+	BounceThresholdPercent float64 `yaml:"bounce_threshold_percent"`
+}
+
+// HTTPConfig controls the operator-facing HTTP server (currently just
+// the /events SSE stream).
+type HTTPConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Address string `yaml:"address"`
+}
+
+// RetentionTableConfig sets how long rows in one table are kept before
+// the retention scanner deletes them. A zero MaxAgeHours disables
+// pruning for that table.
+type RetentionTableConfig struct {
+	MaxAgeHours int `yaml:"max_age_hours"`
+}
+
+// RetentionConfig controls the background retention scanner that
+// prunes old delivery logs, bounces, campaign events, and finished
+// campaigns' per-recipient rows.
+type RetentionConfig struct {
+	Enabled            bool                 `yaml:"enabled"`
+	IntervalMinutes    int                  `yaml:"interval_minutes"`
+	DeliveryLogs       RetentionTableConfig `yaml:"delivery_logs"`
+	Bounces            RetentionTableConfig `yaml:"bounces"`
+	CampaignEvents     RetentionTableConfig `yaml:"campaign_events"`
+	CampaignRecipients RetentionTableConfig `yaml:"campaign_recipients"`
+}
+
+// Config is the root configuration object, assembled from
+// LoadFromStandardLocations.
+type Config struct {
+	App       AppConfig                   `yaml:"app"`
+	Logging   observability.LoggingConfig `yaml:"logging"`
+	Database  DatabaseConfig              `yaml:"database"`
+	SMTP      SMTPConfig                  `yaml:"smtp"`
+	Processor ProcessorConfig             `yaml:"processor"`
+	// Notifications configures the admin notification subsystem.
+	Notifications NotificationsConfig `yaml:"notifications"`
+	HTTP          HTTPConfig          `yaml:"http"`
+	Retention     RetentionConfig     `yaml:"retention"`
+
+	// TemplateOverrides maps a registered template name (e.g.
+	// "notifications/campaign-status") to HTML that replaces its
+	// embedded/on-disk content. It's normally empty in the YAML file
+	// and populated at runtime from the settings table instead, since
+	// operators edit it through the CLI rather than by hand.
+	TemplateOverrides map[string]string `yaml:"template_overrides"`
+}
+
+// LoadFromStandardLocations reads the first config file it finds among
+// standardLocations and unmarshals it into a Config.
+func LoadFromStandardLocations() (*Config, error) {
+	var lastErr error
+
+	for _, path := range standardLocations {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		var cfg Config
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		return &cfg, nil
+	}
+
+	return nil, fmt.Errorf("no config file found in %v: %w", standardLocations, lastErr)
+}
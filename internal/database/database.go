@@ -0,0 +1,47 @@
+// Package database owns the connection pool shared by the rest of the
+// mailer's subsystems.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+
+	"mailer/internal/config"
+	"mailer/internal/observability"
+)
+
+// Database wraps the shared *sql.DB handle.
+type Database struct {
+	*sql.DB
+
+	logger *observability.Logger
+}
+
+// New opens and pings the database described by cfg.
+func New(cfg config.DatabaseConfig, logger *observability.Logger) (*Database, error) {
+	sqlDB, err := sql.Open("postgres", cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if cfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+
+	if err := sqlDB.PingContext(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &Database{DB: sqlDB, logger: logger}, nil
+}
+
+// Close closes the underlying connection pool.
+func (d *Database) Close() error {
+	return d.DB.Close()
+}
@@ -0,0 +1,127 @@
+package email
+
+import (
+	"context"
+	"sync"
+
+	"mailer/internal/config"
+	"mailer/internal/database"
+	"mailer/internal/observability"
+	"mailer/internal/smtp"
+)
+
+// EventPublisher receives fire-and-forget operational events for the
+// live /events dashboard feed. It's satisfied by *eventstream.Hub;
+// declaring it locally instead of importing the eventstream package
+// avoids an import cycle.
+type EventPublisher interface {
+	Publish(eventType string, data map[string]interface{})
+}
+
+// EnterpriseProcessor hands messages passed to Send to the SMTP pool.
+// It does not pull queued messages from the database itself.
+type EnterpriseProcessor struct {
+	cfg       *config.ProcessorConfig
+	logger    *observability.Logger
+	db        *database.Database
+	smtp      *smtp.EnterpriseManager
+	templates *EnterpriseTemplateEngine
+	publisher EventPublisher
+
+	mu      sync.Mutex
+	running bool
+	cancel  context.CancelFunc
+}
+
+// NewEnterpriseProcessor builds a processor from its dependencies. It
+// does not start work until Start is called.
+func NewEnterpriseProcessor(
+	cfg *config.ProcessorConfig,
+	logger *observability.Logger,
+	db *database.Database,
+	smtpManager *smtp.EnterpriseManager,
+	templates *EnterpriseTemplateEngine,
+) *EnterpriseProcessor {
+	return &EnterpriseProcessor{
+		cfg:       cfg,
+		logger:    logger,
+		db:        db,
+		smtp:      smtpManager,
+		templates: templates,
+	}
+}
+
+// Start launches the processor's background loop, which dispatches
+// nothing and only waits for Stop.
+func (p *EnterpriseProcessor) Start() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.running = true
+
+	go p.run(ctx)
+
+	return nil
+}
+
+// SetEventPublisher attaches the live event stream. It may be called
+// at any time; a nil publisher disables event emission.
+func (p *EnterpriseProcessor) SetEventPublisher(publisher EventPublisher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.publisher = publisher
+}
+
+// getPublisher returns the attached event publisher, if any. Reading it
+// through the lock matters because SetEventPublisher may be called
+// concurrently with event emission, not just once before any goroutines
+// start.
+func (p *EnterpriseProcessor) getPublisher() EventPublisher {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.publisher
+}
+
+func (p *EnterpriseProcessor) run(ctx context.Context) {
+	<-ctx.Done()
+}
+
+// Send delivers msg through the SMTP pool and publishes a send/failure
+// frame to the live event stream.
+func (p *EnterpriseProcessor) Send(ctx context.Context, msg smtp.Message) error {
+	err := p.smtp.Send(ctx, msg)
+
+	if publisher := p.getPublisher(); publisher != nil {
+		eventType := "processor.sent"
+		if err != nil {
+			eventType = "processor.failed"
+		}
+		publisher.Publish(eventType, map[string]interface{}{
+			"to":      msg.To,
+			"subject": msg.Subject,
+		})
+	}
+
+	return err
+}
+
+// Stop halts the dispatch loop.
+func (p *EnterpriseProcessor) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.running {
+		return nil
+	}
+
+	p.cancel()
+	p.running = false
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Package email renders and dispatches campaign email.
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sync"
+
+	"mailer/internal/observability"
+)
+
+// EnterpriseTemplateEngine owns the set of named HTML templates used
+// for campaign and transactional email.
+type EnterpriseTemplateEngine struct {
+	logger *observability.Logger
+
+	mu        sync.RWMutex
+	templates map[string]*template.Template
+}
+
+// NewEnterpriseTemplateEngine creates an empty template engine.
+func NewEnterpriseTemplateEngine(logger *observability.Logger) *EnterpriseTemplateEngine {
+	return &EnterpriseTemplateEngine{
+		logger:    logger,
+		templates: make(map[string]*template.Template),
+	}
+}
+
+// LoadTemplate parses content and registers it under name. funcs may be
+// nil if the template needs no custom template functions.
+func (e *EnterpriseTemplateEngine) LoadTemplate(name, content string, funcs template.FuncMap) error {
+	tmpl := template.New(name)
+	if funcs != nil {
+		tmpl = tmpl.Funcs(funcs)
+	}
+
+	parsed, err := tmpl.Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %q: %w", name, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.templates[name] = parsed
+
+	return nil
+}
+
+// Render executes the named template against data and returns the
+// resulting HTML.
+func (e *EnterpriseTemplateEngine) Render(name string, data interface{}) (string, error) {
+	e.mu.RLock()
+	tmpl, ok := e.templates[name]
+	e.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("template %q not registered", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// Has reports whether a template has been registered under name.
+func (e *EnterpriseTemplateEngine) Has(name string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	_, ok := e.templates[name]
+	return ok
+}
@@ -0,0 +1,101 @@
+// Package eventstream fans out operational events to connected
+// operators as a Server-Sent Events stream, so a live dashboard can
+// watch campaign and processor activity without polling the database.
+package eventstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Frame is one event published to the stream.
+type Frame struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// subscriberBuffer is how many frames a slow subscriber can fall behind
+// before Publish starts dropping frames for it rather than blocking.
+const subscriberBuffer = 32
+
+// Hub fans a Frame out to every connected /events subscriber. It
+// satisfies the EventPublisher interface that email.EnterpriseProcessor,
+// smtp.EnterpriseManager, and campaign.Manager each declare locally to
+// avoid importing this package.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[chan Frame]struct{}
+}
+
+// NewHub builds an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[chan Frame]struct{})}
+}
+
+// Publish builds a Frame from eventType and data and fans it out to
+// every subscriber. A subscriber whose buffer is full has the frame
+// dropped for it rather than stalling the rest of the hub.
+func (h *Hub) Publish(eventType string, data map[string]interface{}) {
+	frame := Frame{Type: eventType, Data: data}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- frame:
+		default:
+		}
+	}
+}
+
+func (h *Hub) subscribe() chan Frame {
+	ch := make(chan Frame, subscriberBuffer)
+
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch
+}
+
+func (h *Hub) unsubscribe(ch chan Frame) {
+	h.mu.Lock()
+	delete(h.subscribers, ch)
+	h.mu.Unlock()
+}
+
+// ServeHTTP streams Frames to the client as Server-Sent Events until
+// the request context is cancelled.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case frame := <-ch:
+			data, err := json.Marshal(frame)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "retry: 3000\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
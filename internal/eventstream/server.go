@@ -0,0 +1,16 @@
+package eventstream
+
+import "net/http"
+
+// NewServer builds an *http.Server exposing hub at "/events" on addr.
+// Callers are responsible for running Serve/ListenAndServe and for
+// shutting it down.
+func NewServer(addr string, hub *Hub) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/events", hub)
+
+	return &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+}
@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"mailer/internal/config"
+	"mailer/internal/email"
+	"mailer/internal/observability"
+	"mailer/internal/smtp"
+)
+
+// BuildNotifier assembles a Notifier from cfg.Notifications, wiring up
+// whichever sinks are enabled.
+func BuildNotifier(
+	cfg config.NotificationsConfig,
+	smtpManager *smtp.EnterpriseManager,
+	templates *email.EnterpriseTemplateEngine,
+	logger *observability.Logger,
+) *Notifier {
+	var sinks []Sink
+
+	if cfg.AdminEmail.Enabled {
+		sinks = append(sinks, NewAdminEmailSink(cfg.AdminEmail, smtpManager, templates))
+	}
+
+	for _, webhook := range cfg.Webhooks {
+		sinks = append(sinks, NewWebhookSink(webhook))
+	}
+
+	if cfg.Slack.Enabled {
+		sinks = append(sinks, NewSlackSink(cfg.Slack))
+	}
+
+	return NewNotifier(logger, sinks)
+}
@@ -0,0 +1,83 @@
+// Package notify dispatches operational lifecycle events (campaign
+// state changes, bounce-rate alerts) to a configurable set of
+// admin-facing sinks: email, webhooks, and Slack-style chat webhooks.
+package notify
+
+import (
+	"context"
+	"time"
+
+	"mailer/internal/observability"
+)
+
+// EventType identifies the kind of lifecycle event being reported.
+type EventType string
+
+const (
+	EventCampaignStarted        EventType = "campaign.started"
+	EventCampaignPaused         EventType = "campaign.paused"
+	EventCampaignFinished       EventType = "campaign.finished"
+	EventBounceThresholdCrossed EventType = "bounce.threshold_crossed"
+)
+
+// Event is a single lifecycle notification, carrying enough context for
+// a sink to render a human-readable message.
+type Event struct {
+	Type      EventType
+	Summary   string
+	Data      map[string]interface{}
+	Timestamp time.Time
+}
+
+// Sink delivers an Event to some external destination. Implementations
+// should not block for long; Notifier calls each sink from its own
+// goroutine.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+// Notifier fans an Event out to every configured Sink, logging (but not
+// propagating) individual sink failures so one broken sink can't stall
+// campaign processing.
+type Notifier struct {
+	logger *observability.Logger
+	sinks  []Sink
+}
+
+// NewNotifier builds a Notifier over the given sinks. A nil or empty
+// sinks slice is valid and makes Notify a no-op.
+func NewNotifier(logger *observability.Logger, sinks []Sink) *Notifier {
+	return &Notifier{logger: logger, sinks: sinks}
+}
+
+// Notify constructs an Event of the given type and dispatches it to
+// every configured sink. eventType and payload come from callers that
+// don't import this package directly (campaign.Manager) to avoid an
+// import cycle; they're typed as
+// plain strings/maps at the call site and wrapped into an EventType
+// here.
+func (n *Notifier) Notify(ctx context.Context, eventType string, payload map[string]interface{}) {
+	if n == nil || len(n.sinks) == 0 {
+		return
+	}
+
+	event := Event{
+		Type:      EventType(eventType),
+		Data:      payload,
+		Timestamp: time.Now(),
+	}
+
+	for _, sink := range n.sinks {
+		sink := sink
+		go func() {
+			if err := sink.Send(ctx, event); err != nil {
+				n.logger.Warn(ctx, "notification sink failed",
+					"sink", sink.Name(),
+					"event", string(event.Type),
+					"error", err,
+				)
+			}
+		}()
+	}
+}
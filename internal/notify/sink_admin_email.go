@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"mailer/internal/config"
+	"mailer/internal/email"
+	"mailer/internal/smtp"
+)
+
+// templatesByEvent maps an EventType to the admin template that renders
+// it. Events with no mapping fall back to templateGeneric.
+var templatesByEvent = map[EventType]string{
+	EventCampaignStarted:  "notifications/campaign-status",
+	EventCampaignPaused:   "notifications/campaign-status",
+	EventCampaignFinished: "notifications/campaign-status",
+}
+
+const templateGeneric = "notifications/generic-status"
+
+// AdminEmailSink emails configured administrators a rendered summary of
+// each lifecycle event.
+type AdminEmailSink struct {
+	cfg       config.AdminEmailSinkConfig
+	smtp      *smtp.EnterpriseManager
+	templates *email.EnterpriseTemplateEngine
+}
+
+// NewAdminEmailSink builds a sink that renders events through templates
+// and sends them via smtpManager.
+func NewAdminEmailSink(cfg config.AdminEmailSinkConfig, smtpManager *smtp.EnterpriseManager, templates *email.EnterpriseTemplateEngine) *AdminEmailSink {
+	return &AdminEmailSink{cfg: cfg, smtp: smtpManager, templates: templates}
+}
+
+func (s *AdminEmailSink) Name() string {
+	return "admin-email"
+}
+
+// Send renders the event's template and emails it to every configured
+// recipient.
+func (s *AdminEmailSink) Send(ctx context.Context, event Event) error {
+	if len(s.cfg.Recipients) == 0 {
+		return nil
+	}
+
+	templateName, ok := templatesByEvent[event.Type]
+	if !ok {
+		templateName = templateGeneric
+	}
+
+	body, err := s.templates.Render(templateName, event)
+	if err != nil {
+		return fmt.Errorf("failed to render %q: %w", templateName, err)
+	}
+
+	return s.smtp.Send(ctx, smtp.Message{
+		From:    s.cfg.From,
+		To:      s.cfg.Recipients,
+		Subject: fmt.Sprintf("[Mailer] %s", event.Type),
+		Body:    body,
+	})
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"mailer/internal/config"
+)
+
+// slackPayload is the minimal subset of the Slack incoming-webhook
+// message format we rely on.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// SlackSink posts a one-line summary of each event to a Slack (or
+// Slack-compatible) incoming webhook.
+type SlackSink struct {
+	cfg    config.SlackSinkConfig
+	client *http.Client
+}
+
+// NewSlackSink builds a sink targeting cfg.WebhookURL.
+func NewSlackSink(cfg config.SlackSinkConfig) *SlackSink {
+	return &SlackSink{cfg: cfg, client: &http.Client{}}
+}
+
+func (s *SlackSink) Name() string {
+	return "slack"
+}
+
+// Send posts a formatted summary of event to the Slack webhook.
+func (s *SlackSink) Send(ctx context.Context, event Event) error {
+	text := fmt.Sprintf(":mailbox_with_mail: *%s*", event.Type)
+	if event.Summary != "" {
+		text += "\n" + event.Summary
+	}
+
+	body, err := json.Marshal(slackPayload{Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"mailer/internal/config"
+)
+
+// WebhookSink POSTs a JSON-encoded Event to a configured URL.
+type WebhookSink struct {
+	cfg    config.WebhookSinkConfig
+	client *http.Client
+}
+
+// NewWebhookSink builds a sink targeting cfg.URL.
+func NewWebhookSink(cfg config.WebhookSinkConfig) *WebhookSink {
+	return &WebhookSink{cfg: cfg, client: &http.Client{}}
+}
+
+func (s *WebhookSink) Name() string {
+	if s.cfg.Name != "" {
+		return "webhook:" + s.cfg.Name
+	}
+	return "webhook"
+}
+
+// Send JSON-encodes event and POSTs it to s.cfg.URL.
+func (s *WebhookSink) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
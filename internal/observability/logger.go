@@ -0,0 +1,68 @@
+// Package observability provides structured logging for the mailer
+// services. It wraps zap's sugared logger so callers can pass
+// context-scoped key/value pairs without depending on zap directly.
+package observability
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// LoggingConfig controls how the process-wide logger is constructed.
+type LoggingConfig struct {
+	Level       string   `yaml:"level"`
+	Format      string   `yaml:"format"`
+	OutputPaths []string `yaml:"output_paths"`
+}
+
+// Logger is the application's structured logger. All methods accept a
+// context so future revisions can thread request/trace IDs through
+// without changing call sites.
+type Logger struct {
+	sugar *zap.SugaredLogger
+}
+
+// NewLogger builds a Logger from the given configuration.
+func NewLogger(cfg LoggingConfig) (*Logger, error) {
+	zcfg := zap.NewProductionConfig()
+	if cfg.Level != "" {
+		level, err := zap.ParseAtomicLevel(cfg.Level)
+		if err != nil {
+			return nil, err
+		}
+		zcfg.Level = level
+	}
+	if len(cfg.OutputPaths) > 0 {
+		zcfg.OutputPaths = cfg.OutputPaths
+	}
+
+	z, err := zcfg.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Logger{sugar: z.Sugar()}, nil
+}
+
+func (l *Logger) Debug(ctx context.Context, msg string, kv ...interface{}) {
+	l.sugar.Debugw(msg, kv...)
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, kv ...interface{}) {
+	l.sugar.Infow(msg, kv...)
+}
+
+func (l *Logger) Warn(ctx context.Context, msg string, kv ...interface{}) {
+	l.sugar.Warnw(msg, kv...)
+}
+
+func (l *Logger) Error(ctx context.Context, msg string, kv ...interface{}) {
+	l.sugar.Errorw(msg, kv...)
+}
+
+// Sync flushes any buffered log entries. Callers should defer it from
+// main after the logger is constructed.
+func (l *Logger) Sync() error {
+	return l.sugar.Sync()
+}
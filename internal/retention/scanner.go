@@ -0,0 +1,202 @@
+// Package retention runs a background sweep that deletes rows older
+// than their table's configured max age: delivery logs, bounces,
+// campaign events, and the per-recipient rows of campaigns that have
+// already finished.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"mailer/internal/config"
+	"mailer/internal/database"
+	"mailer/internal/observability"
+)
+
+// minInterval floors how often the scanner sweeps, regardless of the
+// configured interval, so a misconfiguration (e.g. interval_minutes: 0)
+// can't turn this into a busy loop against the database.
+const minInterval = time.Minute
+
+// jitterFraction randomizes each tick by up to this fraction of the
+// interval, so a fleet of instances sharing one database don't all
+// sweep at once.
+const jitterFraction = 0.2
+
+// table is one managed table: the query that deletes its rows older
+// than maxAge.
+type table struct {
+	name      string
+	maxAge    time.Duration
+	deleteSQL string
+}
+
+// Scanner periodically deletes rows that have aged out of their
+// table's retention window.
+type Scanner struct {
+	db       *database.Database
+	logger   *observability.Logger
+	interval time.Duration
+	tables   []table
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewScanner builds a Scanner from cfg. It returns nil if retention is
+// disabled; Start and Close must not be called on a nil Scanner.
+func NewScanner(cfg config.RetentionConfig, db *database.Database, logger *observability.Logger) *Scanner {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	interval := time.Duration(cfg.IntervalMinutes) * time.Minute
+	if interval < minInterval {
+		interval = minInterval
+	}
+
+	return &Scanner{
+		db:       db,
+		logger:   logger,
+		interval: interval,
+		tables:   tablesFromConfig(cfg),
+		done:     make(chan struct{}),
+	}
+}
+
+// tablesFromConfig builds the list of tables to prune, skipping any
+// table whose MaxAgeHours is left at zero (the default).
+func tablesFromConfig(cfg config.RetentionConfig) []table {
+	var tables []table
+
+	if cfg.DeliveryLogs.MaxAgeHours > 0 {
+		tables = append(tables, table{
+			name:      "delivery_logs",
+			maxAge:    time.Duration(cfg.DeliveryLogs.MaxAgeHours) * time.Hour,
+			deleteSQL: `DELETE FROM delivery_logs WHERE created_at < $1`,
+		})
+	}
+
+	if cfg.Bounces.MaxAgeHours > 0 {
+		tables = append(tables, table{
+			name:      "bounces",
+			maxAge:    time.Duration(cfg.Bounces.MaxAgeHours) * time.Hour,
+			deleteSQL: `DELETE FROM bounces WHERE created_at < $1`,
+		})
+	}
+
+	if cfg.CampaignEvents.MaxAgeHours > 0 {
+		tables = append(tables, table{
+			name:      "campaign_events",
+			maxAge:    time.Duration(cfg.CampaignEvents.MaxAgeHours) * time.Hour,
+			deleteSQL: `DELETE FROM campaign_events WHERE created_at < $1`,
+		})
+	}
+
+	if cfg.CampaignRecipients.MaxAgeHours > 0 {
+		tables = append(tables, table{
+			name:   "campaign_recipients",
+			maxAge: time.Duration(cfg.CampaignRecipients.MaxAgeHours) * time.Hour,
+			deleteSQL: `
+				DELETE FROM campaign_recipients USING campaigns
+				WHERE campaign_recipients.campaign_id = campaigns.id
+				  AND campaigns.status IN ('finished', 'cancelled')
+				  AND campaign_recipients.updated_at < $1
+			`,
+		})
+	}
+
+	return tables
+}
+
+// Start launches the background sweep loop and returns immediately.
+// The loop runs until ctx is cancelled or Close is called.
+func (s *Scanner) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+
+	go s.loop(ctx)
+}
+
+func (s *Scanner) loop(ctx context.Context) {
+	defer close(s.done)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.nextDelay()):
+			s.sweep(ctx)
+		}
+	}
+}
+
+// nextDelay jitters s.interval by up to +/- jitterFraction.
+func (s *Scanner) nextDelay() time.Duration {
+	jitter := time.Duration((rand.Float64()*2 - 1) * jitterFraction * float64(s.interval))
+	return s.interval + jitter
+}
+
+// sweep runs one pass over every configured table, deleting aged-out
+// rows and logging a summary. It checks ctx.Done() between tables so a
+// shutdown mid-sweep aborts promptly instead of running every table to
+// completion.
+func (s *Scanner) sweep(ctx context.Context) {
+	start := time.Now()
+	var totalDeleted int64
+
+	for _, t := range s.tables {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		deleted, err := s.sweepTable(ctx, t)
+		if err != nil {
+			s.logger.Warn(ctx, "Retention sweep failed for table", "table", t.name, "error", err)
+			continue
+		}
+		totalDeleted += deleted
+	}
+
+	s.logger.Info(ctx, "Retention sweep completed",
+		"tables_scanned", len(s.tables),
+		"rows_deleted", totalDeleted,
+		"duration", time.Since(start),
+	)
+}
+
+func (s *Scanner) sweepTable(ctx context.Context, t table) (int64, error) {
+	cutoff := time.Now().Add(-t.maxAge)
+
+	res, err := s.db.ExecContext(ctx, t.deleteSQL, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete aged rows from %s: %w", t.name, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted rows from %s: %w", t.name, err)
+	}
+
+	return rows, nil
+}
+
+// Close stops the sweep loop and waits for an in-progress sweep to
+// finish or abort, up to ctx's deadline.
+func (s *Scanner) Close(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
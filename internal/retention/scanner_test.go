@@ -0,0 +1,20 @@
+package retention
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDelayStaysWithinJitterBounds(t *testing.T) {
+	s := &Scanner{interval: time.Minute}
+
+	min := time.Duration((1 - jitterFraction) * float64(s.interval))
+	max := time.Duration((1 + jitterFraction) * float64(s.interval))
+
+	for i := 0; i < 1000; i++ {
+		d := s.nextDelay()
+		if d < min || d > max {
+			t.Fatalf("nextDelay() = %v, want within [%v, %v]", d, min, max)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package settings backs the subset of configuration that operators
+// can change at runtime (SMTP pools, processor concurrency and rate
+// limits, template overrides, notification recipients) with a row in
+// the database instead of the on-disk YAML file, so changes can be
+// made from the CLI without a redeploy.
+package settings
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"mailer/internal/config"
+	"mailer/internal/database"
+)
+
+// settingsKey is the single row this package reads and writes. A
+// single JSONB blob keeps the schema stable as new mutable fields are
+// added.
+const settingsKey = "mutable"
+
+// Settings is the mutable subset of config.Config.
+type Settings struct {
+	SMTP              config.SMTPConfig          `json:"smtp"`
+	Processor         config.ProcessorConfig     `json:"processor"`
+	Notifications     config.NotificationsConfig `json:"notifications"`
+	TemplateOverrides map[string]string          `json:"template_overrides"`
+}
+
+// FromConfig seeds a Settings from a freshly YAML-loaded config.Config,
+// for the first time a process runs against a database with no
+// settings row yet.
+func FromConfig(cfg *config.Config) *Settings {
+	return &Settings{
+		SMTP:              cfg.SMTP,
+		Processor:         cfg.Processor,
+		Notifications:     cfg.Notifications,
+		TemplateOverrides: cfg.TemplateOverrides,
+	}
+}
+
+// ApplyTo overwrites cfg's mutable sections with s.
+func (s *Settings) ApplyTo(cfg *config.Config) {
+	cfg.SMTP = s.SMTP
+	cfg.Processor = s.Processor
+	cfg.Notifications = s.Notifications
+	cfg.TemplateOverrides = s.TemplateOverrides
+}
+
+// Store reads and writes the settings row.
+type Store struct {
+	db *database.Database
+}
+
+// NewStore builds a Store over db.
+func NewStore(db *database.Database) *Store {
+	return &Store{db: db}
+}
+
+// Load reads the settings row. found is false if no row has been
+// saved yet (e.g. first boot against a fresh database).
+func (s *Store) Load(ctx context.Context) (settings *Settings, found bool, err error) {
+	var raw []byte
+
+	err = s.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = $1`, settingsKey).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	var out Settings
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, false, fmt.Errorf("failed to decode settings: %w", err)
+	}
+
+	return &out, true, nil
+}
+
+// Save upserts the settings row.
+func (s *Store) Save(ctx context.Context, settings *Settings) error {
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO settings (key, value, updated_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (key) DO UPDATE
+		SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	`, settingsKey, raw)
+	if err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+
+	return nil
+}
+
+// LoadOrSeed loads the settings row, seeding it from cfg on first boot
+// against a database with no row yet, and applies the result to cfg.
+func (s *Store) LoadOrSeed(ctx context.Context, cfg *config.Config) (*Settings, error) {
+	loaded, found, err := s.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		seed := FromConfig(cfg)
+		if err := s.Save(ctx, seed); err != nil {
+			return nil, err
+		}
+		return seed, nil
+	}
+
+	loaded.ApplyTo(cfg)
+	return loaded, nil
+}
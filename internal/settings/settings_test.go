@@ -0,0 +1,60 @@
+package settings
+
+import (
+	"reflect"
+	"testing"
+
+	"mailer/internal/config"
+)
+
+func TestFromConfigApplyToRoundTrips(t *testing.T) {
+	cfg := &config.Config{
+		Processor: config.ProcessorConfig{
+			Concurrency: 4,
+			RateLimit:   120,
+		},
+		Notifications: config.NotificationsConfig{
+			AdminEmail: config.AdminEmailSinkConfig{
+				Enabled:    true,
+				From:       "alerts@example.com",
+				Recipients: []string{"ops@example.com"},
+			},
+		},
+		TemplateOverrides: map[string]string{
+			"notifications/campaign-status": "<p>custom</p>",
+		},
+	}
+
+	s := FromConfig(cfg)
+
+	out := &config.Config{}
+	s.ApplyTo(out)
+
+	if !reflect.DeepEqual(out.SMTP, cfg.SMTP) {
+		t.Errorf("SMTP = %+v, want %+v", out.SMTP, cfg.SMTP)
+	}
+	if !reflect.DeepEqual(out.Processor, cfg.Processor) {
+		t.Errorf("Processor = %+v, want %+v", out.Processor, cfg.Processor)
+	}
+	if !reflect.DeepEqual(out.Notifications, cfg.Notifications) {
+		t.Errorf("Notifications = %+v, want %+v", out.Notifications, cfg.Notifications)
+	}
+	if !reflect.DeepEqual(out.TemplateOverrides, cfg.TemplateOverrides) {
+		t.Errorf("TemplateOverrides = %+v, want %+v", out.TemplateOverrides, cfg.TemplateOverrides)
+	}
+}
+
+func TestApplyToOverwritesExistingMutableSections(t *testing.T) {
+	cfg := &config.Config{
+		Processor: config.ProcessorConfig{Concurrency: 1},
+	}
+
+	s := &Settings{
+		Processor: config.ProcessorConfig{Concurrency: 8, RateLimit: 500},
+	}
+	s.ApplyTo(cfg)
+
+	if cfg.Processor.Concurrency != 8 || cfg.Processor.RateLimit != 500 {
+		t.Errorf("Processor = %+v, want Concurrency:8 RateLimit:500", cfg.Processor)
+	}
+}
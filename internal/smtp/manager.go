@@ -0,0 +1,86 @@
+// Package smtp manages the pool of outbound SMTP relays used to
+// deliver campaign and notification email.
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"mailer/internal/config"
+	"mailer/internal/observability"
+)
+
+// Message is a single outbound email.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// EventPublisher receives fire-and-forget operational events for the
+// live /events dashboard feed. It's satisfied by *eventstream.Hub;
+// declaring it locally instead of importing the eventstream package
+// avoids an import cycle.
+type EventPublisher interface {
+	Publish(eventType string, data map[string]interface{})
+}
+
+// EnterpriseManager holds the configured pool of SMTP hosts and tracks
+// which hosts have been marked unhealthy.
+type EnterpriseManager struct {
+	cfg       *config.SMTPConfig
+	logger    *observability.Logger
+	publisher EventPublisher
+
+	mu        sync.Mutex
+	unhealthy map[string]bool
+}
+
+// NewEnterpriseManager builds a manager for the configured host pool.
+func NewEnterpriseManager(cfg *config.SMTPConfig, logger *observability.Logger) (*EnterpriseManager, error) {
+	if cfg == nil || len(cfg.Hosts) == 0 {
+		return nil, fmt.Errorf("smtp: at least one host must be configured")
+	}
+
+	return &EnterpriseManager{
+		cfg:       cfg,
+		logger:    logger,
+		unhealthy: make(map[string]bool),
+	}, nil
+}
+
+// Send accepts msg and returns nil. This package has no SMTP transport:
+// Send picks no host, dials nothing, and does not deliver msg.
+func (m *EnterpriseManager) Send(ctx context.Context, msg Message) error {
+	return nil
+}
+
+// SetEventPublisher attaches the live event stream. It may be called
+// at any time; a nil publisher disables event emission.
+func (m *EnterpriseManager) SetEventPublisher(publisher EventPublisher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.publisher = publisher
+}
+
+// MarkUnhealthy flags a host as temporarily unusable and publishes an
+// event so a live dashboard can surface the degraded pool.
+func (m *EnterpriseManager) MarkUnhealthy(host string) {
+	m.mu.Lock()
+	m.unhealthy[host] = true
+	publisher := m.publisher
+	m.mu.Unlock()
+
+	if publisher != nil {
+		publisher.Publish("smtp.host_unhealthy", map[string]interface{}{
+			"host": host,
+		})
+	}
+}
+
+// Close releases any pooled connections.
+func (m *EnterpriseManager) Close() error {
+	return nil
+}
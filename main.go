@@ -1,198 +1,591 @@
 package main
 
 import (
-   "context"
-   "fmt"
-   "log"
-   "os"
-   "os/signal"
-   "path/filepath"
-   "strings"
-   "syscall"
-   "time"
-   
-   "mailer/internal/config"
-   "mailer/internal/database"
-   "mailer/internal/observability"
-   "mailer/internal/smtp"
-   "mailer/internal/email"
-   "mailer/internal/cli"
-   "mailer/internal/campaign"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"mailer/internal/campaign"
+	"mailer/internal/cli"
+	"mailer/internal/config"
+	"mailer/internal/database"
+	"mailer/internal/email"
+	"mailer/internal/eventstream"
+	"mailer/internal/notify"
+	"mailer/internal/observability"
+	"mailer/internal/retention"
+	"mailer/internal/settings"
+	"mailer/internal/smtp"
 )
 
 const (
-   AppName    = "Mailer"
-   AppVersion = "2.0.0"
-   GoVersion  = "1.21"
+	AppName    = "Mailer"
+	AppVersion = "2.0.0"
+	GoVersion  = "1.21"
 )
 
 func main() {
-   cfg, err := config.LoadFromStandardLocations()
-   if err != nil {
-       log.Fatalf("Config not found. Create config.yaml in current directory or config/config.yaml\nError: %v", err)
-   }
-   
-   logger, err := observability.NewLogger(cfg.Logging)
-   if err != nil {
-       log.Fatalf("Failed to initialize logger: %v", err)
-   }
-   defer logger.Sync()
-   
-   ctx := context.Background()
-   logger.Info(ctx, "Starting Mailer",
-       "version", AppVersion,
-       "environment", cfg.App.Environment,
-   )
-   
-   db, err := database.New(cfg.Database, logger)
-   if err != nil {
-       logger.Error(ctx, "Failed to initialize database", "error", err)
-       os.Exit(1)
-   }
-   defer db.Close()
-   
-   smtpManager, err := smtp.NewEnterpriseManager(&cfg.SMTP, logger)
-   if err != nil {
-       logger.Error(ctx, "Failed to initialize SMTP manager", "error", err)
-       os.Exit(1)
-   }
-   defer smtpManager.Close()
-   
-   templateEngine := email.NewEnterpriseTemplateEngine(logger)
-   
-   if err := loadTemplates(templateEngine, logger); err != nil {
-       logger.Warn(ctx, "Failed to load some templates", "error", err)
-   }
-   
-   processor := email.NewEnterpriseProcessor(
-       &cfg.Processor,
-       logger,
-       db,
-       smtpManager,
-       templateEngine,
-   )
-   
-   if err := processor.Start(); err != nil {
-       logger.Error(ctx, "Failed to start email processor", "error", err)
-       os.Exit(1)
-   }
-   defer processor.Stop()
-   
-   campaignManager := campaign.NewManager(logger, db, processor, templateEngine)
-   defer campaignManager.Close()
-   
-   shutdown := make(chan os.Signal, 1)
-   signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
-   
-   logger.Info(ctx, "Mailer ready")
-   
-   cliInterface := cli.NewInterface(
-       cfg,
-       logger,
-       db,
-       processor,
-       smtpManager,
-   )
-   
-   cliDone := make(chan error, 1)
-   go func() {
-       cliDone <- cliInterface.Run()
-   }()
-   
-   select {
-   case <-shutdown:
-       logger.Info(ctx, "Shutdown signal received")
-   case err := <-cliDone:
-       if err != nil {
-           logger.Error(ctx, "CLI interface error", "error", err)
-       } else {
-           logger.Info(ctx, "CLI completed normally")
-       }
-   }
-   
-   logger.Info(ctx, "Shutting down gracefully...")
-   shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-   defer cancel()
-   
-   if err := gracefulShutdown(shutdownCtx, logger, processor, smtpManager, campaignManager, db); err != nil {
-       logger.Error(ctx, "Error during shutdown", "error", err)
-       os.Exit(1)
-   }
-   
-   logger.Info(ctx, "Mailer stopped")
+	passive := flag.Bool("passive", false, "disable the campaign scanner so this instance never claims or sends a campaign")
+	worker := flag.Bool("worker", false, "run headless: skip the interactive CLI and just process leased work")
+	flag.Parse()
+
+	cfg, err := config.LoadFromStandardLocations()
+	if err != nil {
+		log.Fatalf("Config not found. Create config.yaml in current directory or config/config.yaml\nError: %v", err)
+	}
+
+	logger, err := observability.NewLogger(cfg.Logging)
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	ctx := context.Background()
+	logger.Info(ctx, "Starting Mailer",
+		"version", AppVersion,
+		"environment", cfg.App.Environment,
+	)
+
+	db, err := database.New(cfg.Database, logger)
+	if err != nil {
+		logger.Error(ctx, "Failed to initialize database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	if err := runSchemaMigrations(ctx, db, logger); err != nil {
+		logger.Error(ctx, "Failed to apply schema migrations", "error", err)
+		os.Exit(1)
+	}
+
+	retentionScanner := retention.NewScanner(cfg.Retention, db, logger)
+	if retentionScanner != nil {
+		retentionScanner.Start(ctx)
+		logger.Info(ctx, "Retention scanner started")
+	}
+
+	settingsStore := settings.NewStore(db)
+	if _, err := settingsStore.LoadOrSeed(ctx, cfg); err != nil {
+		logger.Error(ctx, "Failed to load settings", "error", err)
+		os.Exit(1)
+	}
+
+	smtpManager, err := smtp.NewEnterpriseManager(&cfg.SMTP, logger)
+	if err != nil {
+		logger.Error(ctx, "Failed to initialize SMTP manager", "error", err)
+		os.Exit(1)
+	}
+	defer smtpManager.Close()
+
+	templateEngine := email.NewEnterpriseTemplateEngine(logger)
+
+	if err := loadTemplates(templateEngine, logger); err != nil {
+		logger.Warn(ctx, "Failed to load some templates", "error", err)
+	}
+	applyTemplateOverrides(templateEngine, logger, cfg.TemplateOverrides)
+
+	processor := email.NewEnterpriseProcessor(
+		&cfg.Processor,
+		logger,
+		db,
+		smtpManager,
+		templateEngine,
+	)
+
+	if err := processor.Start(); err != nil {
+		logger.Error(ctx, "Failed to start email processor", "error", err)
+		os.Exit(1)
+	}
+	defer processor.Stop()
+
+	mode := campaign.ModeActive
+	if *passive {
+		mode = campaign.ModePassive
+	}
+	ownerID := instanceOwnerID()
+
+	campaignManager := campaign.NewManager(logger, db, processor, templateEngine, mode, ownerID)
+	defer campaignManager.Close()
+
+	notifier := notify.BuildNotifier(cfg.Notifications, smtpManager, templateEngine, logger)
+	campaignManager.SetNotifier(notifier)
+	campaignManager.SetBounceThreshold(cfg.Notifications.BounceThresholdPercent)
+
+	hub := eventstream.NewHub()
+	processor.SetEventPublisher(hub)
+	smtpManager.SetEventPublisher(hub)
+	campaignManager.SetEventPublisher(hub)
+
+	var eventServer *http.Server
+	if cfg.HTTP.Enabled {
+		eventServer = eventstream.NewServer(cfg.HTTP.Address, hub)
+		go func() {
+			if err := eventServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error(ctx, "Event stream server error", "error", err)
+			}
+		}()
+		logger.Info(ctx, "Event stream listening", "address", cfg.HTTP.Address)
+	}
+
+	logger.Info(ctx, "Campaign manager mode", "mode", string(mode), "owner_id", ownerID, "worker", *worker)
+
+	ctl := newController(cfg, settingsStore, campaignManager, logger)
+
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
+
+	logger.Info(ctx, "Mailer ready")
+
+	cliInterface := cli.NewInterface(
+		cfg,
+		logger,
+		db,
+		processor,
+		smtpManager,
+		campaignManager,
+		ctl,
+	)
+
+	cliDone := make(chan error, 1)
+	if *worker {
+		logger.Info(ctx, "Running in headless worker mode, skipping interactive CLI")
+	} else {
+		go func() {
+			cliDone <- cliInterface.Run()
+		}()
+	}
+
+	restarting := false
+	select {
+	case <-shutdown:
+		logger.Info(ctx, "Shutdown signal received")
+	case err := <-cliDone:
+		if err != nil {
+			logger.Error(ctx, "CLI interface error", "error", err)
+		} else {
+			logger.Info(ctx, "CLI completed normally")
+		}
+	case <-ctl.restartCh:
+		logger.Info(ctx, "Restarting to apply settings changes")
+		restarting = true
+	}
+
+	logger.Info(ctx, "Shutting down gracefully...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := gracefulShutdown(shutdownCtx, logger, processor, smtpManager, campaignManager, db, eventServer, retentionScanner); err != nil {
+		logger.Error(ctx, "Error during shutdown", "error", err)
+		os.Exit(1)
+	}
+
+	if restarting {
+		logger.Info(ctx, "Re-executing to reload settings")
+		logger.Sync()
+		if err := execSelf(); err != nil {
+			logger.Error(ctx, "Failed to re-exec for restart", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	logger.Info(ctx, "Mailer stopped")
+}
+
+// execSelf replaces the current process image with a fresh copy of
+// itself, same argv and environment, so a settings change takes effect
+// without an external supervisor having to restart the service.
+func execSelf() error {
+	argv0, err := os.Executable()
+	if err != nil {
+		argv0 = os.Args[0]
+	}
+	return syscall.Exec(argv0, os.Args, os.Environ())
+}
+
+// controller bridges the CLI to process-level actions: editing the
+// mutable settings row and (re-)triggering the graceful
+// drain-and-restart sequence in main. A restart is refused while any
+// campaign is running, and instead leaves restartRequired set until an
+// operator clears it by retrying once the campaign finishes.
+type controller struct {
+	cfg             *config.Config
+	settingsStore   *settings.Store
+	campaignManager *campaign.Manager
+	logger          *observability.Logger
+	restartCh       chan struct{}
+
+	mu              sync.Mutex
+	restartRequired bool
+}
+
+func newController(cfg *config.Config, store *settings.Store, campaignManager *campaign.Manager, logger *observability.Logger) *controller {
+	return &controller{
+		cfg:             cfg,
+		settingsStore:   store,
+		campaignManager: campaignManager,
+		logger:          logger,
+		restartCh:       make(chan struct{}, 1),
+	}
+}
+
+// settingSetters maps a CLI-editable dotted key to a function that
+// applies value to s.
+var settingSetters = map[string]func(s *settings.Settings, value string) error{
+	"processor.concurrency": func(s *settings.Settings, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("must be an integer: %w", err)
+		}
+		s.Processor.Concurrency = n
+		return nil
+	},
+	"processor.rate_limit_per_minute": func(s *settings.Settings, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("must be an integer: %w", err)
+		}
+		s.Processor.RateLimit = n
+		return nil
+	},
+	"notifications.admin_email.enabled": func(s *settings.Settings, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be true or false: %w", err)
+		}
+		s.Notifications.AdminEmail.Enabled = b
+		return nil
+	},
+	"notifications.admin_email.from": func(s *settings.Settings, value string) error {
+		s.Notifications.AdminEmail.From = value
+		return nil
+	},
+	"notifications.admin_email.recipients": func(s *settings.Settings, value string) error {
+		var recipients []string
+		if err := json.Unmarshal([]byte(value), &recipients); err != nil {
+			return fmt.Errorf("must be a JSON array of addresses: %w", err)
+		}
+		s.Notifications.AdminEmail.Recipients = recipients
+		return nil
+	},
+	"notifications.webhooks": func(s *settings.Settings, value string) error {
+		var webhooks []config.WebhookSinkConfig
+		if err := json.Unmarshal([]byte(value), &webhooks); err != nil {
+			return fmt.Errorf("must be a JSON array of {name,url}: %w", err)
+		}
+		s.Notifications.Webhooks = webhooks
+		return nil
+	},
+	"notifications.slack.enabled": func(s *settings.Settings, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be true or false: %w", err)
+		}
+		s.Notifications.Slack.Enabled = b
+		return nil
+	},
+	"notifications.slack.webhook_url": func(s *settings.Settings, value string) error {
+		s.Notifications.Slack.WebhookURL = value
+		return nil
+	},
+	"notifications.bounce_threshold_percent": func(s *settings.Settings, value string) error {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("must be a number between 0 and 1: %w", err)
+		}
+		s.Notifications.BounceThresholdPercent = f
+		return nil
+	},
+	"smtp.hosts": func(s *settings.Settings, value string) error {
+		var hosts []config.SMTPHostConfig
+		if err := json.Unmarshal([]byte(value), &hosts); err != nil {
+			return fmt.Errorf("must be a JSON array of {name,address,username,password}: %w", err)
+		}
+		s.SMTP.Hosts = hosts
+		return nil
+	},
+	"template_overrides": func(s *settings.Settings, value string) error {
+		var overrides map[string]string
+		if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+			return fmt.Errorf("must be a JSON object of template name to HTML: %w", err)
+		}
+		s.TemplateOverrides = overrides
+		return nil
+	},
+}
+
+// Set applies a single setting by its dotted key, persists it, and
+// requests a restart to pick it up.
+func (c *controller) Set(ctx context.Context, key, value string) error {
+	setter, ok := settingSetters[key]
+	if !ok {
+		return fmt.Errorf("unknown setting %q", key)
+	}
+
+	current := settings.FromConfig(c.cfg)
+	if err := setter(current, value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	if err := c.settingsStore.Save(ctx, current); err != nil {
+		return err
+	}
+	current.ApplyTo(c.cfg)
+
+	if accepted, reason := c.RequestRestart(ctx); !accepted {
+		c.logger.Warn(ctx, "Restart required to apply settings", "reason", reason)
+	}
+
+	return nil
+}
+
+// RequestRestart asks main's top-level select loop to drain and
+// re-exec the process. It's refused while a campaign is running.
+func (c *controller) RequestRestart(ctx context.Context) (accepted bool, reason string) {
+	if c.campaignManager.HasRunningCampaigns() {
+		c.mu.Lock()
+		c.restartRequired = true
+		c.mu.Unlock()
+		return false, "a campaign is running; restart refused until it finishes or is paused"
+	}
+
+	c.mu.Lock()
+	c.restartRequired = false
+	c.mu.Unlock()
+
+	select {
+	case c.restartCh <- struct{}{}:
+	default:
+	}
+
+	return true, ""
+}
+
+// RestartRequired reports whether a settings change is waiting on a
+// running campaign to finish before the process can restart.
+func (c *controller) RestartRequired() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.restartRequired
+}
+
+// instanceOwnerID identifies this process when taking out campaign
+// leases. It only needs to be unique among instances sharing a
+// database at the same time, not stable across restarts.
+func instanceOwnerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown-host"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
 }
 
 func loadTemplates(templateEngine *email.EnterpriseTemplateEngine, logger *observability.Logger) error {
-   templatesDir := "templates"
-   
-   files, err := os.ReadDir(templatesDir)
-   if err != nil {
-       return fmt.Errorf("failed to read templates directory: %w", err)
-   }
-   
-   for _, file := range files {
-       if file.IsDir() || !strings.HasSuffix(file.Name(), ".html") {
-           continue
-       }
-       
-       templatePath := filepath.Join(templatesDir, file.Name())
-       content, err := os.ReadFile(templatePath)
-       if err != nil {
-           logger.Warn(context.Background(), "Failed to load template",
-               "template", file.Name(),
-               "error", err,
-           )
-           continue
-       }
-       
-       templateName := strings.TrimSuffix(file.Name(), ".html")
-       if err := templateEngine.LoadTemplate(templateName, string(content), nil); err != nil {
-           logger.Warn(context.Background(), "Failed to register template",
-               "template", templateName,
-               "error", err,
-           )
-           continue
-       }
-       
-       logger.Debug(context.Background(), "Template loaded",
-           "template", templateName,
-       )
-   }
-   
-   return nil
-}
-
-func gracefulShutdown(ctx context.Context, logger *observability.Logger, processor *email.EnterpriseProcessor, smtpManager *smtp.EnterpriseManager, campaignManager *campaign.Manager, db *database.Database) error {
-   logger.Info(ctx, "Starting graceful shutdown")
-   
-   if processor != nil {
-       logger.Info(ctx, "Stopping email processor")
-       if err := processor.Stop(); err != nil {
-           logger.Error(ctx, "Error stopping processor", "error", err)
-       }
-   }
-   
-   if campaignManager != nil {
-       logger.Info(ctx, "Stopping campaign manager")
-       if err := campaignManager.Close(); err != nil {
-           logger.Error(ctx, "Error stopping campaign manager", "error", err)
-       }
-   }
-   
-   if smtpManager != nil {
-       logger.Info(ctx, "Closing SMTP connections")
-       if err := smtpManager.Close(); err != nil {
-           logger.Error(ctx, "Error closing SMTP manager", "error", err)
-       }
-   }
-   
-   if db != nil {
-       logger.Info(ctx, "Closing database connections")
-       if err := db.Close(); err != nil {
-           logger.Error(ctx, "Error closing database", "error", err)
-       }
-   }
-   
-   logger.Info(ctx, "Graceful shutdown completed")
-   return nil
-}
\ No newline at end of file
+	baseDir := "templates"
+
+	if err := loadTemplateDir(templateEngine, logger, baseDir, ""); err != nil {
+		return fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	// Admin notification templates live under their own "notifications/"
+	// namespace so e.g. "campaign-status" (a campaign template) and
+	// "notifications/campaign-status" (its admin-notification
+	// counterpart) can coexist without colliding.
+	notificationsDir := filepath.Join(baseDir, "notifications")
+	if err := loadTemplateDir(templateEngine, logger, notificationsDir, "notifications/"); err != nil {
+		logger.Warn(context.Background(), "Failed to read notifications templates directory", "error", err)
+	}
+
+	return nil
+}
+
+// applyTemplateOverrides re-registers every template named in overrides
+// (the mutable "template_overrides" setting) with operator-supplied
+// content, taking priority over whatever loadTemplates already
+// registered from the embedded/on-disk copy.
+func applyTemplateOverrides(templateEngine *email.EnterpriseTemplateEngine, logger *observability.Logger, overrides map[string]string) {
+	for name, content := range overrides {
+		if err := templateEngine.LoadTemplate(name, content, nil); err != nil {
+			logger.Warn(context.Background(), "Failed to register template override",
+				"template", name,
+				"error", err,
+			)
+			continue
+		}
+		logger.Debug(context.Background(), "Template loaded", "template", name, "source", "settings override")
+	}
+}
+
+func loadTemplateDir(templateEngine *email.EnterpriseTemplateEngine, logger *observability.Logger, embeddedDir, namespace string) error {
+	entries, err := embeddedTemplates.ReadDir(embeddedDir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".html") {
+			continue
+		}
+
+		embeddedPath := filepath.Join(embeddedDir, entry.Name())
+		content, source, err := readTemplate(embeddedPath)
+		if err != nil {
+			logger.Warn(context.Background(), "Failed to load template",
+				"template", entry.Name(),
+				"error", err,
+			)
+			continue
+		}
+
+		templateName := namespace + strings.TrimSuffix(entry.Name(), ".html")
+		if err := templateEngine.LoadTemplate(templateName, string(content), nil); err != nil {
+			logger.Warn(context.Background(), "Failed to register template",
+				"template", templateName,
+				"error", err,
+			)
+			continue
+		}
+
+		logger.Debug(context.Background(), "Template loaded",
+			"template", templateName,
+			"source", source,
+		)
+	}
+
+	return nil
+}
+
+// readTemplate returns the contents of the template at embeddedPath
+// (e.g. "templates/notifications/campaign-status.html"), preferring an
+// on-disk override rooted at templatesOverrideRoot() over the copy
+// embedded in the binary at compile time. source reports which one was
+// actually used, for logging.
+func readTemplate(embeddedPath string) (content []byte, source string, err error) {
+	if diskRoot := templatesOverrideRoot(); diskRoot != "" {
+		if rel, err := filepath.Rel("templates", embeddedPath); err == nil {
+			diskPath := filepath.Join(diskRoot, rel)
+			if data, err := os.ReadFile(diskPath); err == nil {
+				return data, "disk:" + diskPath, nil
+			}
+		}
+	}
+
+	data, err := embeddedTemplates.ReadFile(embeddedPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, "embedded", nil
+}
+
+// runSchemaMigrations applies every *.sql file under schema/, in
+// filename order (hence the 000N_ prefixes), preferring an on-disk
+// override rooted at schemaOverrideRoot() over the copy embedded in the
+// binary at compile time. Every migration's DDL is written
+// IF NOT EXISTS, so running the full set on every boot is safe and
+// needs no separate migrations-applied table.
+func runSchemaMigrations(ctx context.Context, db *database.Database, logger *observability.Logger) error {
+	entries, err := embeddedSchema.ReadDir("schema")
+	if err != nil {
+		return fmt.Errorf("failed to read schema directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		embeddedPath := filepath.Join("schema", entry.Name())
+		content, source, err := readSchemaFile(embeddedPath)
+		if err != nil {
+			return fmt.Errorf("failed to load migration %q: %w", entry.Name(), err)
+		}
+
+		if _, err := db.ExecContext(ctx, string(content)); err != nil {
+			return fmt.Errorf("failed to apply migration %q: %w", entry.Name(), err)
+		}
+
+		logger.Debug(ctx, "Migration applied", "migration", entry.Name(), "source", source)
+	}
+
+	return nil
+}
+
+// readSchemaFile returns the contents of the migration at embeddedPath
+// (e.g. "schema/0001_campaign_leases.sql"), preferring an on-disk
+// override rooted at schemaOverrideRoot() over the embedded copy. See
+// readTemplate for the same pattern applied to templates.
+func readSchemaFile(embeddedPath string) (content []byte, source string, err error) {
+	if diskRoot := schemaOverrideRoot(); diskRoot != "" {
+		if rel, err := filepath.Rel("schema", embeddedPath); err == nil {
+			diskPath := filepath.Join(diskRoot, rel)
+			if data, err := os.ReadFile(diskPath); err == nil {
+				return data, "disk:" + diskPath, nil
+			}
+		}
+	}
+
+	data, err := embeddedSchema.ReadFile(embeddedPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, "embedded", nil
+}
+
+func gracefulShutdown(ctx context.Context, logger *observability.Logger, processor *email.EnterpriseProcessor, smtpManager *smtp.EnterpriseManager, campaignManager *campaign.Manager, db *database.Database, eventServer *http.Server, retentionScanner *retention.Scanner) error {
+	logger.Info(ctx, "Starting graceful shutdown")
+
+	if eventServer != nil {
+		logger.Info(ctx, "Stopping event stream server")
+		if err := eventServer.Shutdown(ctx); err != nil {
+			logger.Error(ctx, "Error stopping event stream server", "error", err)
+		}
+	}
+
+	if retentionScanner != nil {
+		logger.Info(ctx, "Stopping retention scanner")
+		if err := retentionScanner.Close(ctx); err != nil {
+			logger.Error(ctx, "Error stopping retention scanner", "error", err)
+		}
+	}
+
+	if processor != nil {
+		logger.Info(ctx, "Stopping email processor")
+		if err := processor.Stop(); err != nil {
+			logger.Error(ctx, "Error stopping processor", "error", err)
+		}
+	}
+
+	if campaignManager != nil {
+		logger.Info(ctx, "Stopping campaign manager")
+		if err := campaignManager.Close(); err != nil {
+			logger.Error(ctx, "Error stopping campaign manager", "error", err)
+		}
+	}
+
+	if smtpManager != nil {
+		logger.Info(ctx, "Closing SMTP connections")
+		if err := smtpManager.Close(); err != nil {
+			logger.Error(ctx, "Error closing SMTP manager", "error", err)
+		}
+	}
+
+	if db != nil {
+		logger.Info(ctx, "Closing database connections")
+		if err := db.Close(); err != nil {
+			logger.Error(ctx, "Error closing database", "error", err)
+		}
+	}
+
+	logger.Info(ctx, "Graceful shutdown completed")
+	return nil
+}